@@ -12,6 +12,14 @@ import (
 	"github.com/tmthrgd/atomics"
 )
 
+// Atomic is a bitset that is safe for concurrent use.
+//
+// Every method that touches a single uint64 word, such
+// as Set or IsSet, does so atomically. Methods that
+// touch several words, such as SetRange, Count, Equal
+// or ShiftLeft, load or modify each word atomically but
+// are not atomic as a whole: concurrent writers may be
+// seen to have modified some words and not others.
 type Atomic []atomics.Uint64
 
 func NewAtomic(size uint) Atomic {
@@ -0,0 +1,112 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MarshalBinary encodes a in the same format as
+// Bitset.MarshalBinary.
+//
+// Each uint64 word is loaded atomically, but writers
+// are not stopped, so the encoding is not a consistent
+// snapshot of a as a whole.
+func (a Atomic) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, uint64(a.Len()), a.snapshot), nil
+}
+
+// UnmarshalBinary decodes either an encoded Atomic or
+// an encoded Bitset, rounding the length up to a
+// multiple of 64 bits.
+//
+// It is not safe to call UnmarshalBinary concurrently
+// with any other method.
+func (a *Atomic) UnmarshalBinary(data []byte) error {
+	payload, bits, err := decodeBinary(data)
+	if err != nil {
+		return err
+	}
+
+	a1, err := atomicFromBytes(payload, bits)
+	if err != nil {
+		return err
+	}
+
+	*a = a1
+	return nil
+}
+
+// WriteTo writes a in the same format as MarshalBinary
+// without buffering the whole encoding.
+func (a Atomic) WriteTo(w io.Writer) (int64, error) {
+	return writeBinary(w, uint64(a.Len()), func(w io.Writer) error {
+		var buf [4096]byte
+
+		for a := a; len(a) > 0; {
+			n := len(a)
+			if n > len(buf)/8 {
+				n = len(buf) / 8
+			}
+
+			a[:n].snapshot(buf[:n*8])
+
+			if _, err := w.Write(buf[:n*8]); err != nil {
+				return err
+			}
+
+			a = a[n:]
+		}
+
+		return nil
+	})
+}
+
+// ReadFrom reads a single Atomic, as written by WriteTo,
+// from r. Unlike most implementations of io.ReaderFrom,
+// it does not read until io.EOF.
+//
+// It is not safe to call ReadFrom concurrently with any
+// other method.
+func (a *Atomic) ReadFrom(r io.Reader) (int64, error) {
+	payload, bits, n, err := readBinary(r)
+	if err != nil {
+		return n, err
+	}
+
+	a1, err := atomicFromBytes(payload, bits)
+	if err != nil {
+		return n, err
+	}
+
+	*a = a1
+	return n, nil
+}
+
+// snapshot loads each word of a into b, which must be
+// 8*len(a) bytes long.
+func (a Atomic) snapshot(b []byte) {
+	for i := range a {
+		binary.LittleEndian.PutUint64(b[i*8:], a[i].Load())
+	}
+}
+
+func atomicFromBytes(b []byte, bits uint64) (Atomic, error) {
+	if bits > uint64(^uint(0)&^63) {
+		return nil, errBinaryTooLarge
+	}
+
+	a := NewAtomic(uint(bits))
+
+	for i := range a {
+		var w [8]byte
+		copy(w[:], b[i*8:])
+		a[i].Store(binary.LittleEndian.Uint64(w[:]))
+	}
+
+	return a, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+)
+
+func TestAtomicMarshalBinary(t *testing.T) {
+	if err := quick.Check(func(size, start, end uint) bool {
+		a := NewAtomic(size)
+		a.SetRange(start, end)
+
+		data, err := a.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		b := New(a.Len())
+		b.SetRange(start, end)
+
+		if data1, _ := b.MarshalBinary(); !bytes.Equal(data, data1) {
+			return false
+		}
+
+		var a1 Atomic
+		if err := a1.UnmarshalBinary(data); err != nil || a1.Len() != a.Len() {
+			return false
+		}
+
+		for i := range a {
+			if a[i].Load() != a1[i].Load() {
+				return false
+			}
+		}
+
+		return true
+	}, &quick.Config{
+		Values:        rangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicUnmarshalBinaryBitset(t *testing.T) {
+	b := New(72)
+	b.Set(1)
+	b.Set(70)
+
+	data, _ := b.MarshalBinary()
+
+	var a Atomic
+	if err := a.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Len() != 128 {
+		t.Errorf("UnmarshalBinary failed, expected Len of 128, got %d", a.Len())
+	}
+
+	if a[0].Load() != 1<<1 || a[1].Load() != 1<<(70-64) {
+		t.Error("UnmarshalBinary failed")
+	}
+}
+
+func TestAtomicFromBytesTooLarge(t *testing.T) {
+	if _, err := atomicFromBytes(nil, ^uint64(0)); err != errBinaryTooLarge {
+		t.Errorf("atomicFromBytes returned %v, expected %v", err, errBinaryTooLarge)
+	}
+
+	if _, err := atomicFromBytes(nil, uint64(^uint(0))); err != errBinaryTooLarge {
+		t.Errorf("atomicFromBytes returned %v, expected %v", err, errBinaryTooLarge)
+	}
+}
+
+func TestAtomicWriteTo(t *testing.T) {
+	a := NewAtomic(1 << 16)
+	a.SetRange(100, 40000)
+
+	var buf bytes.Buffer
+	if _, err := a.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := a.MarshalBinary(); !bytes.Equal(buf.Bytes(), data) {
+		t.Error("WriteTo failed, does not match MarshalBinary")
+	}
+
+	var a1 Atomic
+	if _, err := a1.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range a {
+		if a[i].Load() != a1[i].Load() {
+			t.Fatalf("ReadFrom failed at word %d", i)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+func (a Atomic) Copy(a1 Atomic) {
+	n := len(a)
+	if len(a1) < n {
+		n = len(a1)
+	}
+
+	for i := 0; i < n; i++ {
+		a[i].Store(a1[i].Load())
+	}
+}
+
+func (a Atomic) CopyRange(a1 Atomic, start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() || end > a1.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		ptr1, _ := a1.index(start)
+		atomicStoreMasked(ptr, ptr1.Load(), mask)
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		ptr1, _ := a1.index(i)
+		ptr.Store(ptr1.Load())
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		ptr1, _ := a1.index(end)
+		atomicStoreMasked(ptr, ptr1.Load(), mask)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestAtomicCopy(t *testing.T) {
+	a, a1 := NewAtomic(256), randomAtomic(rand.New(rand.NewSource(1)), 192)
+	a.SetAll()
+	a.Copy(a1)
+
+	if !atomicBitset(a)[:24].Equal(atomicBitset(a1)) || !a.IsRangeSet(192, 256) {
+		t.Error("Copy failed")
+	}
+}
+
+func TestAtomicCopyRange(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		a1 := randomAtomic(rand.New(rand.NewSource(int64(start))), a.Len())
+
+		b, b1 := atomicBitset(a), atomicBitset(a1)
+		b.CopyRange(b1, start, end)
+
+		a.CopyRange(a1, start, end)
+		return atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "math/bits"
+
+func (a Atomic) Count() uint {
+	var total int
+	for i := range a {
+		total += bits.OnesCount64(a[i].Load())
+	}
+
+	return uint(total)
+}
+
+func (a Atomic) CountRange(start, end uint) uint {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	var total int
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		total += bits.OnesCount64(ptr.Load() & mask)
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		total += bits.OnesCount64(ptr.Load())
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		total += bits.OnesCount64(ptr.Load() & mask)
+	}
+
+	return uint(total)
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestAtomicCount(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		return a.Count() == atomicBitset(a).Count()
+	}, &quick.Config{
+		Values: atomicRangeTestValues,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicCountRange(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		return a.CountRange(start, end) == atomicBitset(a).CountRange(start, end)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkAtomicCount(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := NewAtomic(uint(size.l) * 8)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				var _ = bs.Count()
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+func (a Atomic) Equal(a1 Atomic) bool {
+	if len(a) != len(a1) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Load() != a1[i].Load() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "testing"
+
+func TestAtomicEqual(t *testing.T) {
+	a, a1 := NewAtomic(192), NewAtomic(192)
+
+	if !a.Equal(a1) {
+		t.Error("Equal failed for empty Atomics")
+	}
+
+	a.Set(100)
+
+	if a.Equal(a1) {
+		t.Error("Equal failed to detect difference")
+	}
+
+	a1.Set(100)
+
+	if !a.Equal(a1) {
+		t.Error("Equal failed")
+	}
+
+	if a.Equal(a[:2]) {
+		t.Error("Equal failed for different lengths")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// SetBits returns an iterator over the set bits of a,
+// in increasing order.
+//
+// Each uint64 word is loaded atomically once, but
+// the iterator as a whole is not a consistent
+// snapshot of a.
+func (a Atomic) SetBits() iter.Seq[uint] {
+	return a.SetBitsRange(0, a.Len())
+}
+
+// ClearBits returns an iterator over the clear bits
+// of a, in increasing order. It has the same
+// consistency as SetBits.
+func (a Atomic) ClearBits() iter.Seq[uint] {
+	return a.ClearBitsRange(0, a.Len())
+}
+
+// SetBitsRange returns an iterator over the set bits
+// of a in [start, end). It has the same consistency as
+// SetBits.
+func (a Atomic) SetBitsRange(start, end uint) iter.Seq[uint] {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	return func(yield func(uint) bool) {
+		a.iterate(start, end, 0, yield)
+	}
+}
+
+// ClearBitsRange returns an iterator over the clear
+// bits of a in [start, end). It has the same
+// consistency as SetBits.
+func (a Atomic) ClearBitsRange(start, end uint) iter.Seq[uint] {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	return func(yield func(uint) bool) {
+		a.iterate(start, end, ^uint64(0), yield)
+	}
+}
+
+func (a Atomic) iterate(start, end uint, invert uint64, yield func(uint) bool) {
+	for i := start &^ 63; i < end; i += 64 {
+		ptr, _ := a.index(i)
+		w := ptr.Load() ^ invert
+
+		if i < start {
+			w &= ^uint64(0) << (start & 63)
+		}
+
+		if end-i < 64 {
+			w &= 1<<(end-i) - 1
+		}
+
+		for ; w != 0; w &= w - 1 {
+			if !yield(i + uint(bits.TrailingZeros64(w))) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func TestAtomicSetBits(t *testing.T) {
+	a := NewAtomic(192)
+	a.Set(3)
+	a.Set(64)
+	a.Set(191)
+
+	if exp, got := []uint{3, 64, 191}, slices.Collect(a.SetBits()); !slices.Equal(exp, got) {
+		t.Errorf("SetBits failed, expected %v, got %v", exp, got)
+	}
+
+	for i := range a.SetBits() {
+		if i != 3 {
+			t.Errorf("SetBits failed, expected early termination after 3, got %d", i)
+		}
+
+		break
+	}
+}
+
+func TestAtomicClearBits(t *testing.T) {
+	a := NewAtomic(128)
+	a.SetRange(0, a.Len())
+	a.Clear(0)
+	a.Clear(100)
+
+	if exp, got := []uint{0, 100}, slices.Collect(a.ClearBits()); !slices.Equal(exp, got) {
+		t.Errorf("ClearBits failed, expected %v, got %v", exp, got)
+	}
+}
+
+func TestAtomicSetBitsRange(t *testing.T) {
+	if err := quick.Check(func(size, start, end uint) bool {
+		a := NewAtomic(size)
+		a.SetRange(start, end)
+
+		bits := slices.Collect(a.SetBitsRange(start/2, end+(size-end)/2))
+		if len(bits) != int(end-start) {
+			return false
+		}
+
+		for i, bit := range bits {
+			if bit != start+uint(i) {
+				return false
+			}
+		}
+
+		return true
+	}, &quick.Config{
+		Values:        rangeTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicClearBitsRange(t *testing.T) {
+	if err := quick.Check(func(size, start, end uint) bool {
+		a := NewAtomic(size)
+		a.SetRange(0, a.Len())
+		a.ClearRange(start, end)
+
+		bits := slices.Collect(a.ClearBitsRange(0, a.Len()))
+		if len(bits) != int(end-start) {
+			return false
+		}
+
+		for i, bit := range bits {
+			if bit != start+uint(i) {
+				return false
+			}
+		}
+
+		return true
+	}, &quick.Config{
+		Values:        rangeTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
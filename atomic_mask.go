@@ -18,5 +18,24 @@ func atomicMask1(start, end uint) (mask uint64) {
 
 func atomicMask2(start, end uint) (mask uint64) {
 	const shiftBy = 31 + 32*(^uint(0)>>63)
-	return ((1 << (end & 63)) - 1) & uint64((((end&^63-start)>>shiftBy)&1)-1)
+	return ((1 << (end & 63)) - 1) & (uint64(((end&^63-start)>>shiftBy)&1) - 1)
+}
+
+// atomicStoreMasked atomically replaces the bits of *ptr
+// selected by mask with those of v.
+func atomicStoreMasked(ptr *atomics.Uint64, v, mask uint64) {
+	old := ptr.Load()
+	for !ptr.CompareAndSwap(old, old&^mask|v&mask) {
+		old = ptr.Load()
+	}
+}
+
+// word atomically loads the i'th word of a, treating
+// words past either end of a as zero.
+func (a Atomic) word(i int) uint64 {
+	if i < 0 || i >= len(a) {
+		return 0
+	}
+
+	return a[i].Load()
 }
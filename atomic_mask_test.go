@@ -57,6 +57,20 @@ func TestAtomicMask2(t *testing.T) {
 		}
 	}
 
+	for _, v := range []struct {
+		start, end uint
+		mask       uint64
+	}{
+		{0, 33, 1<<33 - 1},
+		{64, 127, 1<<63 - 1},
+		{10, 100, 1<<36 - 1},
+		{70, 100, 0},
+	} {
+		if mask := atomicMask2(v.start, v.end); mask != v.mask {
+			t.Errorf("atomicMask2(%d, %d) = 0x%x, expected 0x%x", v.start, v.end, mask, v.mask)
+		}
+	}
+
 	if err := quick.CheckEqual(testAtomicMask2, atomicMask2, &quick.Config{
 		Values:        maskTestValues,
 		MaxCountScale: 500,
@@ -17,3 +17,77 @@ func (a Atomic) IsSet(bit uint) bool {
 func (a Atomic) IsClear(bit uint) bool {
 	return !a.IsSet(bit)
 }
+
+func (a Atomic) IsRangeSet(start, end uint) bool {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		if ptr.Load()&mask != mask {
+			return false
+		}
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		if ptr.Load() != ^uint64(0) {
+			return false
+		}
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		return ptr.Load()&mask == mask
+	}
+
+	return true
+}
+
+func (a Atomic) IsRangeClear(start, end uint) bool {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		if ptr.Load()&mask != 0 {
+			return false
+		}
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		if ptr.Load() != 0 {
+			return false
+		}
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		return ptr.Load()&mask == 0
+	}
+
+	return true
+}
+
+func (a Atomic) All() bool {
+	return a.IsRangeSet(0, a.Len())
+}
+
+func (a Atomic) None() bool {
+	return a.IsRangeClear(0, a.Len())
+}
+
+func (a Atomic) Any() bool {
+	return !a.None()
+}
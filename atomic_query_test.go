@@ -5,7 +5,10 @@
 
 package bitset
 
-import "testing"
+import (
+	"testing"
+	"testing/quick"
+)
 
 func TestAtomicIsSet(t *testing.T) {
 	b := NewAtomic(192)
@@ -47,6 +50,58 @@ func TestAtomicIsClear(t *testing.T) {
 	}
 }
 
+func TestAtomicIsRangeSet(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		if a.IsRangeSet(start, end) != atomicBitset(a).IsRangeSet(start, end) {
+			return false
+		}
+
+		a.SetRange(start, end)
+		return a.IsRangeSet(start, end)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicIsRangeClear(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		if a.IsRangeClear(start, end) != atomicBitset(a).IsRangeClear(start, end) {
+			return false
+		}
+
+		a.ClearRange(start, end)
+		return a.IsRangeClear(start, end)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicAllNoneAny(t *testing.T) {
+	a := NewAtomic(192)
+
+	if a.All() || !a.None() || a.Any() {
+		t.Error("All, None or Any failed for empty Atomic")
+	}
+
+	a.Set(130)
+
+	if a.All() || a.None() || !a.Any() {
+		t.Error("All, None or Any failed")
+	}
+
+	a.SetAll()
+
+	if !a.All() || a.None() || !a.Any() {
+		t.Error("All, None or Any failed for full Atomic")
+	}
+}
+
 func BenchmarkAtomicIsSet(b *testing.B) {
 	bs := NewAtomic(192)
 
@@ -103,6 +103,40 @@ func (a Atomic) ClearRange(start, end uint) {
 	}
 }
 
+func (a Atomic) InvertRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old^mask) {
+			old = ptr.Load()
+		}
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, ^old) {
+			old = ptr.Load()
+		}
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old^mask) {
+			old = ptr.Load()
+		}
+	}
+}
+
 func (a Atomic) SetTo(bit uint, value bool) {
 	if value {
 		a.Set(bit)
@@ -118,3 +152,27 @@ func (a Atomic) SetRangeTo(start, end uint, value bool) {
 		a.ClearRange(start, end)
 	}
 }
+
+func (a Atomic) SetAll() {
+	for i := range a {
+		a[i].Store(^uint64(0))
+	}
+}
+
+func (a Atomic) ClearAll() {
+	for i := range a {
+		a[i].Store(0)
+	}
+}
+
+func (a Atomic) InvertAll() {
+	a.InvertRange(0, a.Len())
+}
+
+func (a Atomic) SetAllTo(value bool) {
+	if value {
+		a.SetAll()
+	} else {
+		a.ClearAll()
+	}
+}
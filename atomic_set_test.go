@@ -122,6 +122,51 @@ func TestAtomicClearRange(t *testing.T) {
 	}
 }
 
+func TestAtomicInvertRange(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		b := atomicBitset(a)
+		b.InvertRange(start, end)
+
+		a.InvertRange(start, end)
+		return atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicSetAll(t *testing.T) {
+	a := NewAtomic(192)
+
+	a.SetAll()
+
+	if a.Count() != 192 {
+		t.Error("SetAll failed")
+	}
+
+	a.InvertAll()
+
+	if a.Count() != 0 {
+		t.Error("InvertAll failed")
+	}
+
+	a.SetAllTo(true)
+	a.Clear(64)
+	a.InvertAll()
+
+	if a.Count() != 1 || !a.IsSet(64) {
+		t.Error("InvertAll failed")
+	}
+
+	a.ClearAll()
+
+	if a.Any() {
+		t.Error("ClearAll failed")
+	}
+}
+
 func BenchmarkAtomicSet(b *testing.B) {
 	bs := NewAtomic(192)
 
@@ -0,0 +1,72 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+// ShiftLeft behaves like Bitset.ShiftLeft. It works
+// forwards through a so that a and a1 may be the same
+// Atomic.
+func (a Atomic) ShiftLeft(a1 Atomic, shift uint) {
+	if shift > a1.Len() {
+		panic(errOutOfRange)
+	}
+
+	l := a1.Len() - shift
+	if a.Len() < l {
+		l = a.Len()
+	}
+
+	q, r := int(shift/64), shift&63
+
+	for j := 0; j < int((l+63)/64); j++ {
+		w := a1.word(j+q)>>r | a1.word(j+q+1)<<(64-r)
+
+		if uint(j+1)*64 > l {
+			atomicStoreMasked(&a[j], w, 1<<(l&63)-1)
+		} else {
+			a[j].Store(w)
+		}
+	}
+}
+
+// ShiftRight behaves like Bitset.ShiftRight. It works
+// backwards through a so that a and a1 may be the same
+// Atomic.
+func (a Atomic) ShiftRight(a1 Atomic, shift uint) {
+	if shift > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	l := a.Len()
+	if a1.Len() < l-shift {
+		l = a1.Len() + shift
+	}
+
+	if l <= shift {
+		return
+	}
+
+	q, r := int(shift/64), shift&63
+	first, last := q, int((l-1)/64)
+
+	for j := last; j >= first; j-- {
+		w := a1.word(j-q)<<r | a1.word(j-q-1)>>(64-r)
+
+		mask := ^uint64(0)
+		if j == last && l&63 != 0 {
+			mask = 1<<(l&63) - 1
+		}
+
+		if j == first {
+			mask &= ^uint64(0) << r
+		}
+
+		if mask == ^uint64(0) {
+			a[j].Store(w)
+		} else {
+			atomicStoreMasked(&a[j], w, mask)
+		}
+	}
+}
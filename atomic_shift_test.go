@@ -0,0 +1,80 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func atomicShiftTestValues(args []reflect.Value, rand *rand.Rand) {
+	a := randomAtomic(rand, uint(rand.Intn(1024)))
+	a1 := randomAtomic(rand, uint(rand.Intn(1024)))
+
+	if rand.Intn(4) == 0 {
+		a1 = a
+	}
+
+	args[0] = reflect.ValueOf(a)
+	args[1] = reflect.ValueOf(a1)
+	args[2] = reflect.ValueOf(uint(rand.Intn(1024)))
+}
+
+func TestAtomicShiftLeft(t *testing.T) {
+	if err := quick.Check(func(a, a1 Atomic, shift uint) bool {
+		if shift > a1.Len() {
+			shift = a1.Len()
+		}
+
+		b, b1 := atomicBitset(a), atomicBitset(a1)
+		b.ShiftLeft(b1, shift)
+
+		a.ShiftLeft(a1, shift)
+		return atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicShiftTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicShiftRight(t *testing.T) {
+	if err := quick.Check(func(a, a1 Atomic, shift uint) bool {
+		if shift > a.Len() {
+			shift = a.Len()
+		}
+
+		b, b1 := atomicBitset(a), atomicBitset(a1)
+		b.ShiftRight(b1, shift)
+
+		a.ShiftRight(a1, shift)
+		return atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicShiftTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkAtomicShiftLeft(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := NewAtomic(uint(size.l) * 8)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				bs.ShiftLeft(bs, 3)
+			}
+		})
+	}
+}
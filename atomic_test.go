@@ -5,7 +5,37 @@
 
 package bitset
 
-import "testing"
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func atomicRangeTestValues(args []reflect.Value, rand *rand.Rand) {
+	size := 1 + rand.Intn(4096)
+
+	start := rand.Intn(size)
+	end := start + rand.Intn(size-start+1)
+
+	args[0] = reflect.ValueOf(randomAtomic(rand, uint(size)))
+	args[1] = reflect.ValueOf(uint(start))
+	args[2] = reflect.ValueOf(uint(end))
+}
+
+func randomAtomic(rand *rand.Rand, size uint) Atomic {
+	a := NewAtomic(size)
+	for i := range a {
+		a[i].Store(uint64(rand.Int63()) ^ uint64(rand.Int63())<<1)
+	}
+
+	return a
+}
+
+func atomicBitset(a Atomic) Bitset {
+	b := make(Bitset, len(a)*8)
+	a.snapshot(b)
+	return b
+}
 
 func TestNewAtomic(t *testing.T) {
 	for _, v := range []struct {
@@ -0,0 +1,187 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var errBitFieldCrossesWord = errors.New("go-bitset: bit field crosses a uint64 boundary")
+
+// TestAndSet sets bit and reports whether it was
+// already set.
+func (a Atomic) TestAndSet(bit uint) bool {
+	if bit > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	ptr, mask := a.index(bit)
+	old := ptr.Load()
+	for !ptr.CompareAndSwap(old, old|mask) {
+		old = ptr.Load()
+	}
+
+	return old&mask != 0
+}
+
+// TestAndClear clears bit and reports whether it was
+// set.
+func (a Atomic) TestAndClear(bit uint) bool {
+	if bit > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	ptr, mask := a.index(bit)
+	old := ptr.Load()
+	for !ptr.CompareAndSwap(old, old&^mask) {
+		old = ptr.Load()
+	}
+
+	return old&mask != 0
+}
+
+// TestAndInvert inverts bit and reports whether it was
+// set.
+func (a Atomic) TestAndInvert(bit uint) bool {
+	if bit > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	ptr, mask := a.index(bit)
+	old := ptr.Load()
+	for !ptr.CompareAndSwap(old, old^mask) {
+		old = ptr.Load()
+	}
+
+	return old&mask != 0
+}
+
+// TestAndSetRange sets the bits in [start, end) and
+// returns how many of them were previously clear.
+func (a Atomic) TestAndSetRange(start, end uint) uint {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	var changed int
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old|mask) {
+			old = ptr.Load()
+		}
+
+		changed += bits.OnesCount64(mask &^ old)
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		changed += 64 - bits.OnesCount64(ptr.Swap(^uint64(0)))
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old|mask) {
+			old = ptr.Load()
+		}
+
+		changed += bits.OnesCount64(mask &^ old)
+	}
+
+	return uint(changed)
+}
+
+// TestAndClearRange clears the bits in [start, end) and
+// returns how many of them were previously set.
+func (a Atomic) TestAndClearRange(start, end uint) uint {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > a.Len() {
+		panic(errOutOfRange)
+	}
+
+	var changed int
+
+	if mask := atomicMask1(start, end); mask != 0 {
+		ptr, _ := a.index(start)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old&^mask) {
+			old = ptr.Load()
+		}
+
+		changed += bits.OnesCount64(mask & old)
+	}
+
+	for i := (start + 63) &^ 63; i < end&^63; i += 64 {
+		ptr, _ := a.index(i)
+		changed += bits.OnesCount64(ptr.Swap(0))
+	}
+
+	if mask := atomicMask2(start, end); mask != 0 {
+		ptr, _ := a.index(end)
+		old := ptr.Load()
+		for !ptr.CompareAndSwap(old, old&^mask) {
+			old = ptr.Load()
+		}
+
+		changed += bits.OnesCount64(mask & old)
+	}
+
+	return uint(changed)
+}
+
+// checkBits panics unless the width bits starting
+// at bit lie within a single uint64 word of a.
+func (a Atomic) checkBits(bit, width uint) {
+	checkBits(a.Len(), bit, width)
+
+	if bit&63+width > 64 {
+		panic(errBitFieldCrossesWord)
+	}
+}
+
+// LoadBits atomically loads the width bits starting at
+// bit. The bits must lie within a single uint64 word.
+func (a Atomic) LoadBits(bit, width uint) uint64 {
+	a.checkBits(bit, width)
+
+	ptr, _ := a.index(bit)
+	return ptr.Load() >> (bit & 63) & widthMask(width)
+}
+
+// CompareAndSwapBits atomically replaces the width bits
+// starting at bit with new if they currently equal old,
+// and reports whether it did so. The bits must lie
+// within a single uint64 word. Bits of old and new above
+// width are ignored.
+func (a Atomic) CompareAndSwapBits(bit, width uint, old, new uint64) bool {
+	a.checkBits(bit, width)
+
+	ptr, _ := a.index(bit)
+	shift := bit & 63
+	mask := widthMask(width) << shift
+	old, new = old<<shift&mask, new<<shift&mask
+
+	for {
+		w := ptr.Load()
+		if w&mask != old {
+			return false
+		}
+
+		if ptr.CompareAndSwap(w, w&^mask|new) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"sync"
+	"testing"
+	"testing/quick"
+)
+
+func TestAtomicTestAndSet(t *testing.T) {
+	a := NewAtomic(192)
+
+	if a.TestAndSet(70) || !a.IsSet(70) {
+		t.Error("TestAndSet failed for clear bit")
+	}
+
+	if !a.TestAndSet(70) || !a.IsSet(70) || a.Count() != 1 {
+		t.Error("TestAndSet failed for set bit")
+	}
+}
+
+func TestAtomicTestAndClear(t *testing.T) {
+	a := NewAtomic(192)
+	a.Set(70)
+
+	if !a.TestAndClear(70) || a.IsSet(70) {
+		t.Error("TestAndClear failed for set bit")
+	}
+
+	if a.TestAndClear(70) || a.Any() {
+		t.Error("TestAndClear failed for clear bit")
+	}
+}
+
+func TestAtomicTestAndInvert(t *testing.T) {
+	a := NewAtomic(192)
+
+	if a.TestAndInvert(70) || !a.IsSet(70) {
+		t.Error("TestAndInvert failed for clear bit")
+	}
+
+	if !a.TestAndInvert(70) || a.Any() {
+		t.Error("TestAndInvert failed for set bit")
+	}
+}
+
+func TestAtomicTestAndSetConcurrent(t *testing.T) {
+	const goroutines = 8
+
+	a := NewAtomic(1 << 12)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		claimed uint
+	)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var n uint
+			for i := uint(0); i < a.Len(); i++ {
+				if !a.TestAndSet(i) {
+					n++
+				}
+			}
+
+			mu.Lock()
+			claimed += n
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if claimed != a.Len() || !a.All() {
+		t.Errorf("%d bits claimed, expected %d", claimed, a.Len())
+	}
+}
+
+func TestAtomicTestAndSetRange(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		expected := (end - start) - a.CountRange(start, end)
+
+		b := atomicBitset(a)
+		b.SetRange(start, end)
+
+		return a.TestAndSetRange(start, end) == expected && atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicTestAndClearRange(t *testing.T) {
+	if err := quick.Check(func(a Atomic, start, end uint) bool {
+		expected := a.CountRange(start, end)
+
+		b := atomicBitset(a)
+		b.ClearRange(start, end)
+
+		return a.TestAndClearRange(start, end) == expected && atomicBitset(a).Equal(b)
+	}, &quick.Config{
+		Values:        atomicRangeTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAtomicTestAndSetRangeHighBits(t *testing.T) {
+	a := NewAtomic(192)
+
+	if n := a.TestAndSetRange(64, 64+40); n != 40 || a.CountRange(64, 128) != 40 {
+		t.Errorf("TestAndSetRange changed %d bits, expected 40", n)
+	}
+
+	if n := a.TestAndSetRange(10, 64+50); n != 64-10+10 || a.Count() != 64-10+50 {
+		t.Errorf("TestAndSetRange changed %d bits, expected 64", n)
+	}
+
+	if n := a.TestAndClearRange(64, 64+36); n != 36 || a.Count() != 64-10+14 {
+		t.Errorf("TestAndClearRange changed %d bits, expected 36", n)
+	}
+}
+
+func TestAtomicCompareAndSwapBits(t *testing.T) {
+	a := NewAtomic(192)
+	a.SetAll()
+
+	if !a.CompareAndSwapBits(70, 10, 0x3ff, 0x123) {
+		t.Fatal("CompareAndSwapBits failed to swap")
+	}
+
+	if v := a.LoadBits(70, 10); v != 0x123 {
+		t.Fatalf("LoadBits returned %#x, expected 0x123", v)
+	}
+
+	if a.Count() != 192-10+4 {
+		t.Fatal("CompareAndSwapBits modified bits outside the field")
+	}
+
+	if a.CompareAndSwapBits(70, 10, 0x3ff, 0) || a.LoadBits(70, 10) != 0x123 {
+		t.Fatal("CompareAndSwapBits swapped with wrong old value")
+	}
+
+	if !a.CompareAndSwapBits(70, 10, 0xf123, 0xfff) || a.LoadBits(70, 10) != 0x3ff {
+		t.Fatal("CompareAndSwapBits did not ignore bits above width")
+	}
+
+	if !a.CompareAndSwapBits(128, 64, ^uint64(0), 42) || a[2].Load() != 42 {
+		t.Fatal("CompareAndSwapBits failed for whole word")
+	}
+
+	for _, v := range []struct{ bit, width uint }{
+		{60, 5}, {1, 64}, {190, 3}, {0, 65},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CompareAndSwapBits did not panic for bit %d, width %d", v.bit, v.width)
+				}
+			}()
+
+			a.CompareAndSwapBits(v.bit, v.width, 0, 0)
+		}()
+	}
+}
+
+func TestAtomicCompareAndSwapBitsConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		increments = 1000
+	)
+
+	a := NewAtomic(64)
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < increments; i++ {
+				for {
+					v := a.LoadBits(20, 16)
+					if a.CompareAndSwapBits(20, 16, v, v+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if v := a.LoadBits(20, 16); v != goroutines*increments {
+		t.Errorf("counter is %d, expected %d", v, goroutines*increments)
+	}
+
+	if a.CountRange(0, 20) != 0 || a.CountRange(36, 64) != 0 {
+		t.Error("CompareAndSwapBits modified bits outside the field")
+	}
+}
+
+func BenchmarkAtomicTestAndSet(b *testing.B) {
+	bs := NewAtomic(192)
+
+	for i := 0; i < b.N; i++ {
+		bs.TestAndSet(50)
+	}
+}
+
+func BenchmarkAtomicCompareAndSwapBits(b *testing.B) {
+	bs := NewAtomic(192)
+
+	for i := 0; i < b.N; i++ {
+		bs.CompareAndSwapBits(70, 10, uint64(i), uint64(i+1))
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// The binary encoding used by MarshalBinary and WriteTo
+// is:
+//
+//	magic    [4]byte "gbst"
+//	version  uint8   1
+//	bits     uint64  little-endian length in bits
+//	payload  []byte  (bits+7)/8 bytes in Bitset order
+//	checksum uint32  little-endian CRC-32 (IEEE) of
+//	                 all preceding bytes
+//
+// Bitset and Atomic share the same encoding, as the
+// little-endian words of an Atomic have the same bit
+// order as a Bitset.
+const (
+	binaryMagic   = "gbst"
+	binaryVersion = 1
+
+	binaryHeaderLen  = len(binaryMagic) + 1 + 8
+	binaryTrailerLen = 4
+)
+
+var (
+	errBinaryInvalid  = errors.New("go-bitset: invalid binary encoding")
+	errBinaryMagic    = errors.New("go-bitset: invalid binary encoding magic")
+	errBinaryVersion  = errors.New("go-bitset: unsupported binary encoding version")
+	errBinaryChecksum = errors.New("go-bitset: binary encoding checksum mismatch")
+	errBinaryTooLarge = errors.New("go-bitset: binary encoding too large for this platform")
+)
+
+func putBinaryHeader(hdr []byte, bits uint64) {
+	copy(hdr, binaryMagic)
+	hdr[len(binaryMagic)] = binaryVersion
+	binary.LittleEndian.PutUint64(hdr[len(binaryMagic)+1:], bits)
+}
+
+func parseBinaryHeader(hdr []byte) (bits, payloadLen uint64, err error) {
+	if string(hdr[:len(binaryMagic)]) != binaryMagic {
+		return 0, 0, errBinaryMagic
+	}
+
+	if hdr[len(binaryMagic)] != binaryVersion {
+		return 0, 0, errBinaryVersion
+	}
+
+	bits = binary.LittleEndian.Uint64(hdr[len(binaryMagic)+1:])
+	payloadLen = bits >> 3
+	if bits&7 != 0 {
+		payloadLen++
+	}
+
+	return bits, payloadLen, nil
+}
+
+// checkBinaryPadding reports whether the bits of the
+// final payload byte past bits are all clear.
+func checkBinaryPadding(payload []byte, bits uint64) bool {
+	return bits&7 == 0 || payload[len(payload)-1]>>(bits&7) == 0
+}
+
+func appendBinary(dst []byte, bits uint64, payload func([]byte)) []byte {
+	n := len(dst)
+	payloadLen := int((bits + 7) >> 3)
+
+	dst = append(dst, make([]byte, binaryHeaderLen+payloadLen+binaryTrailerLen)...)
+	data := dst[n:]
+
+	putBinaryHeader(data, bits)
+	payload(data[binaryHeaderLen : binaryHeaderLen+payloadLen])
+
+	sum := crc32.ChecksumIEEE(data[:binaryHeaderLen+payloadLen])
+	binary.LittleEndian.PutUint32(data[binaryHeaderLen+payloadLen:], sum)
+	return dst
+}
+
+func decodeBinary(data []byte) (payload []byte, bits uint64, err error) {
+	if len(data) < binaryHeaderLen+binaryTrailerLen {
+		return nil, 0, errBinaryInvalid
+	}
+
+	bits, payloadLen, err := parseBinaryHeader(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if payloadLen != uint64(len(data)-binaryHeaderLen-binaryTrailerLen) {
+		return nil, 0, errBinaryInvalid
+	}
+
+	end := len(data) - binaryTrailerLen
+	if crc32.ChecksumIEEE(data[:end]) != binary.LittleEndian.Uint32(data[end:]) {
+		return nil, 0, errBinaryChecksum
+	}
+
+	payload = data[binaryHeaderLen:end]
+	if !checkBinaryPadding(payload, bits) {
+		return nil, 0, errBinaryInvalid
+	}
+
+	return payload, bits, nil
+}
+
+type checksumWriter struct {
+	w   io.Writer
+	n   int64
+	crc uint32
+}
+
+func (cw *checksumWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.crc = crc32.Update(cw.crc, crc32.IEEETable, p[:n])
+	return n, err
+}
+
+func writeBinary(w io.Writer, bits uint64, payload func(io.Writer) error) (int64, error) {
+	cw := &checksumWriter{w: w}
+
+	var hdr [binaryHeaderLen]byte
+	putBinaryHeader(hdr[:], bits)
+
+	if _, err := cw.Write(hdr[:]); err != nil {
+		return cw.n, err
+	}
+
+	if err := payload(cw); err != nil {
+		return cw.n, err
+	}
+
+	var trailer [binaryTrailerLen]byte
+	binary.LittleEndian.PutUint32(trailer[:], cw.crc)
+
+	n, err := w.Write(trailer[:])
+	return cw.n + int64(n), err
+}
+
+type checksumReader struct {
+	r   io.Reader
+	n   int64
+	crc uint32
+}
+
+func (cr *checksumReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	cr.crc = crc32.Update(cr.crc, crc32.IEEETable, p[:n])
+	return n, err
+}
+
+// readBinary reads exactly one encoded bitset from r.
+func readBinary(r io.Reader) (payload []byte, bits uint64, n int64, err error) {
+	cr := &checksumReader{r: r}
+
+	defer func() {
+		if err == io.EOF && cr.n != 0 {
+			err = io.ErrUnexpectedEOF
+		}
+	}()
+
+	var hdr [binaryHeaderLen]byte
+	if _, err := io.ReadFull(cr, hdr[:]); err != nil {
+		return nil, 0, cr.n, err
+	}
+
+	bits, payloadLen, err := parseBinaryHeader(hdr[:])
+	if err != nil {
+		return nil, 0, cr.n, err
+	}
+
+	if payloadLen > uint64(^uint(0)>>1) {
+		return nil, 0, cr.n, errBinaryInvalid
+	}
+
+	// The payload length has not yet been verified, so
+	// grow the buffer as data arrives rather than
+	// trusting it for a single large allocation.
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, cr, int64(payloadLen)); err != nil {
+		return nil, 0, cr.n, err
+	}
+
+	sum := cr.crc
+
+	var trailer [binaryTrailerLen]byte
+	if _, err := io.ReadFull(cr, trailer[:]); err != nil {
+		return nil, 0, cr.n, err
+	}
+
+	if sum != binary.LittleEndian.Uint32(trailer[:]) {
+		return nil, 0, cr.n, errBinaryChecksum
+	}
+
+	payload = buf.Bytes()
+	if !checkBinaryPadding(payload, bits) {
+		return nil, 0, cr.n, errBinaryInvalid
+	}
+
+	return payload, bits, cr.n, nil
+}
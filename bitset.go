@@ -73,5 +73,11 @@ func (b Bitset) String() string {
 		return "Bitset{" + hex.EncodeToString(b[:maxSize]) + "...}"
 	}
 
+	return b.FullString()
+}
+
+// FullString is like String but never truncates the
+// output. Its result can be parsed by Parse.
+func (b Bitset) FullString() string {
 	return "Bitset{" + hex.EncodeToString(b) + "}"
 }
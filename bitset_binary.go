@@ -0,0 +1,44 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "io"
+
+func (b Bitset) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, uint64(b.Len()), func(payload []byte) {
+		copy(payload, b)
+	}), nil
+}
+
+func (b *Bitset) UnmarshalBinary(data []byte) error {
+	payload, _, err := decodeBinary(data)
+	if err != nil {
+		return err
+	}
+
+	*b = Bitset(payload).Clone()
+	return nil
+}
+
+func (b Bitset) WriteTo(w io.Writer) (int64, error) {
+	return writeBinary(w, uint64(b.Len()), func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+}
+
+// ReadFrom reads a single Bitset, as written by WriteTo,
+// from r. Unlike most implementations of io.ReaderFrom,
+// it does not read until io.EOF.
+func (b *Bitset) ReadFrom(r io.Reader) (int64, error) {
+	payload, _, n, err := readBinary(r)
+	if err != nil {
+		return n, err
+	}
+
+	*b = payload
+	return n, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"testing"
+	"testing/quick"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	b := New(16)
+	b.Set(0)
+	b.Set(15)
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []byte{
+		'g', 'b', 's', 't', 1,
+		16, 0, 0, 0, 0, 0, 0, 0,
+		0x01, 0x80,
+		0x12, 0x44, 0xae, 0x51,
+	}
+	if !bytes.Equal(data, exp) {
+		t.Errorf("MarshalBinary failed, expected %x, got %x", exp, data)
+	}
+
+	if err := quick.Check(func(b Bitset) bool {
+		data, err := b.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		var b1 Bitset
+		return b1.UnmarshalBinary(data) == nil && b1.Equal(b)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	b := New(80)
+	b.SetRange(3, 70)
+
+	data, _ := b.MarshalBinary()
+
+	for _, v := range []struct {
+		name string
+		fn   func([]byte) []byte
+		err  error
+	}{
+		{"short", func(d []byte) []byte { return d[:10] }, errBinaryInvalid},
+		{"truncated", func(d []byte) []byte { return d[:len(d)-1] }, errBinaryInvalid},
+		{"magic", func(d []byte) []byte { d[0] = 'x'; return d }, errBinaryMagic},
+		{"version", func(d []byte) []byte { d[4] = 2; return d }, errBinaryVersion},
+		{"payload", func(d []byte) []byte { d[20] ^= 1; return d }, errBinaryChecksum},
+		{"checksum", func(d []byte) []byte { d[len(d)-1] ^= 1; return d }, errBinaryChecksum},
+	} {
+		var b1 Bitset
+		if err := b1.UnmarshalBinary(v.fn(append([]byte(nil), data...))); err != v.err {
+			t.Errorf("UnmarshalBinary did not fail for %s data, expected %v, got %v", v.name, v.err, err)
+		}
+	}
+}
+
+func TestBitsetWriteTo(t *testing.T) {
+	if err := quick.Check(func(b, b1 Bitset) bool {
+		var buf bytes.Buffer
+		if _, err := b.WriteTo(&buf); err != nil {
+			return false
+		}
+
+		if _, err := b1.WriteTo(&buf); err != nil {
+			return false
+		}
+
+		data, _ := b.MarshalBinary()
+		if !bytes.HasPrefix(buf.Bytes(), data) {
+			return false
+		}
+
+		var b2, b3, b4 Bitset
+		if _, err := b2.ReadFrom(&buf); err != nil || !b2.Equal(b) {
+			return false
+		}
+
+		if _, err := b3.ReadFrom(&buf); err != nil || !b3.Equal(b1) {
+			return false
+		}
+
+		_, err := b4.ReadFrom(&buf)
+		return err == io.EOF
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBitsetReadFromTruncated(t *testing.T) {
+	data, _ := New(80).MarshalBinary()
+
+	var b Bitset
+	if _, err := b.ReadFrom(bytes.NewReader(data[:len(data)-5])); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadFrom failed, expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestBitsetGob(t *testing.T) {
+	b := New(80)
+	b.SetRange(3, 70)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(b); err != nil {
+		t.Fatal(err)
+	}
+
+	var b1 Bitset
+	if err := gob.NewDecoder(&buf).Decode(&b1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !b1.Equal(b) {
+		t.Errorf("gob round trip failed, expected %s, got %s", b, b1)
+	}
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				var _, _ = bs.MarshalBinary()
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidWidth = errors.New("go-bitset: bit width must be at most 64")
+
+func checkBits(l, offset, width uint) {
+	if width > 64 {
+		panic(errInvalidWidth)
+	}
+
+	if offset+width < offset || offset+width > l {
+		panic(errOutOfRange)
+	}
+}
+
+func widthMask(width uint) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+
+	return 1<<width - 1
+}
+
+// GetBits returns the width bits starting at offset as an
+// unsigned integer, with bit offset as its least
+// significant bit. width must be at most 64.
+func (b Bitset) GetBits(offset, width uint) uint64 {
+	checkBits(b.Len(), offset, width)
+
+	if width == 0 {
+		return 0
+	}
+
+	i, r := offset>>3, offset&7
+
+	x := loadWord(b, int(i)) >> r
+	if r+width > 64 {
+		x |= uint64(b[i+8]) << (64 - r)
+	}
+
+	return x & widthMask(width)
+}
+
+// GetSignedBits is like GetBits but sign-extends the
+// result, treating the width bits as a two's complement
+// integer.
+func (b Bitset) GetSignedBits(offset, width uint) int64 {
+	return signExtend(b.GetBits(offset, width), width)
+}
+
+func signExtend(x uint64, width uint) int64 {
+	if width == 0 {
+		return 0
+	}
+
+	shift := 64 - width
+	return int64(x<<shift) >> shift
+}
+
+// PutBits stores the low width bits of value starting at
+// offset, with the least significant bit at offset. Bits
+// of value above width are ignored. width must be at most
+// 64.
+func (b Bitset) PutBits(offset, width uint, value uint64) {
+	checkBits(b.Len(), offset, width)
+
+	value &= widthMask(width)
+
+	start, end := offset, offset+width
+	if i, r := start>>3, start&7; i+8 <= uint(len(b)) && r+width <= 64 {
+		m := widthMask(width) << r
+		w := binary.LittleEndian.Uint64(b[i:])
+		binary.LittleEndian.PutUint64(b[i:], w&^m|value<<r)
+		return
+	}
+
+	if mask := mask1(start, end); mask != 0 {
+		b[start>>3] = b[start>>3]&^mask | byte(value<<(start&7))&mask
+		value >>= 8 - start&7
+	}
+
+	for i := (start + 7) &^ 7; i+8 <= end; i += 8 {
+		b[i>>3] = byte(value)
+		value >>= 8
+	}
+
+	if mask := mask2(start, end); mask != 0 {
+		b[end>>3] = b[end>>3]&^mask | byte(value)&mask
+	}
+}
+
+// PutSignedBits stores value as a width bit two's
+// complement integer. Like PutBits, bits of value above
+// width are ignored.
+func (b Bitset) PutSignedBits(offset, width uint, value int64) {
+	b.PutBits(offset, width, uint64(value))
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func bitsTestValues(args []reflect.Value, rand *rand.Rand) {
+	b := New(uint(1 + rand.Intn(256)))
+	rand.Read(b)
+
+	width := uint(rand.Intn(65))
+	if width > b.Len() {
+		width = b.Len()
+	}
+
+	offset := uint(rand.Intn(int(b.Len()-width) + 1))
+
+	args[0] = reflect.ValueOf(b)
+	args[1] = reflect.ValueOf(offset)
+	args[2] = reflect.ValueOf(width)
+	args[3] = reflect.ValueOf(rand.Uint64())
+}
+
+func TestGetBits(t *testing.T) {
+	if err := quick.CheckEqual(func(b Bitset, offset, width uint, _ uint64) (x uint64) {
+		for i := uint(0); i < width; i++ {
+			if b.IsSet(offset + i) {
+				x |= 1 << i
+			}
+		}
+
+		return
+	}, func(b Bitset, offset, width uint, _ uint64) uint64 {
+		return b.GetBits(offset, width)
+	}, &quick.Config{
+		Values:        bitsTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPutBits(t *testing.T) {
+	if err := quick.CheckEqual(func(b Bitset, offset, width uint, value uint64) []byte {
+		b = b.Clone()
+
+		for i := uint(0); i < width; i++ {
+			b.SetTo(offset+i, value&(1<<i) != 0)
+		}
+
+		return b
+	}, func(b Bitset, offset, width uint, value uint64) []byte {
+		b = b.Clone()
+		b.PutBits(offset, width, value)
+		return b
+	}, &quick.Config{
+		Values:        bitsTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSignedBits(t *testing.T) {
+	b := New(80)
+
+	for _, v := range []struct {
+		value int64
+		width uint
+	}{
+		{-1, 1}, {0, 1},
+		{-4, 3}, {3, 3},
+		{-12345, 17},
+		{1<<62 - 1, 63}, {-1 << 62, 63},
+		{-1 << 63, 64}, {1<<63 - 1, 64},
+	} {
+		for _, offset := range []uint{0, 5, 80 - v.width} {
+			b.PutSignedBits(offset, v.width, v.value)
+
+			if x := b.GetSignedBits(offset, v.width); x != v.value {
+				t.Errorf("GetSignedBits(%d, %d) failed, expected %d, got %d", offset, v.width, v.value, x)
+			}
+		}
+	}
+
+	b.PutBits(0, 8, 0xff)
+
+	if x := b.GetSignedBits(0, 8); x != -1 {
+		t.Errorf("GetSignedBits failed, expected -1, got %d", x)
+	}
+
+	if x := b.GetBits(0, 8); x != 0xff {
+		t.Errorf("GetBits failed, expected 255, got %d", x)
+	}
+}
+
+func TestBitsPanics(t *testing.T) {
+	for _, fn := range []func(b Bitset){
+		func(b Bitset) { b.GetBits(0, 65) },
+		func(b Bitset) { b.GetBits(20, 61) },
+		func(b Bitset) { b.PutBits(80, 1, 0) },
+		func(b Bitset) { b.PutBits(^uint(0), 2, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+			}()
+
+			fn(New(80))
+		}()
+	}
+}
+
+func BenchmarkGetBits(b *testing.B) {
+	b1 := New(1024)
+	rand.Read(b1)
+
+	for i := 0; i < b.N; i++ {
+		b1.GetBits(uint(i)&511, 37)
+	}
+}
+
+func BenchmarkPutBits(b *testing.B) {
+	b1 := New(1024)
+
+	for i := 0; i < b.N; i++ {
+		b1.PutBits(uint(i)&511, 37, uint64(i))
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/bits"
+
+	"github.com/tmthrgd/go-bitset/internal/bitwise"
+	"github.com/tmthrgd/go-popcount"
+)
+
+// IntersectionCount returns the number of bits set in
+// both b and b1, without storing the result.
+//
+// Unlike Intersection and the other set operations, the
+// *Count methods do not stop at the end of the shorter
+// Bitset. Instead it is treated as though it were padded
+// with clear bits, so for Bitsets of different lengths
+// the result may differ from counting what Union,
+// Difference or SymmetricDifference would store.
+func (b Bitset) IntersectionCount(b1 Bitset) uint {
+	return uint(bitwise.AndCount(b, b1))
+}
+
+// UnionCount returns the number of bits set in either b
+// or b1, including those past the end of the shorter.
+func (b Bitset) UnionCount(b1 Bitset) uint {
+	n := len(b)
+	if len(b1) < n {
+		n = len(b1)
+	}
+
+	return uint(bitwise.OrCount(b, b1) +
+		popcount.CountBytes(b[n:]) + popcount.CountBytes(b1[n:]))
+}
+
+// DifferenceCount returns the number of bits set in b
+// but not in b1, including those past the end of b1.
+func (b Bitset) DifferenceCount(b1 Bitset) uint {
+	n := len(b)
+	if len(b1) < n {
+		n = len(b1)
+	}
+
+	return uint(bitwise.AndNotCount(b, b1) + popcount.CountBytes(b[n:]))
+}
+
+// SymmetricDifferenceCount returns the number of bits
+// set in exactly one of b and b1, including those past
+// the end of the shorter.
+func (b Bitset) SymmetricDifferenceCount(b1 Bitset) uint {
+	n := len(b)
+	if len(b1) < n {
+		n = len(b1)
+	}
+
+	return uint(bitwise.XORCount(b, b1) +
+		popcount.CountBytes(b[n:]) + popcount.CountBytes(b1[n:]))
+}
+
+func (b Bitset) countRange(b1 Bitset, start, end uint, count func(a, b []byte) uint64, op func(x, y byte) byte) uint {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() || end > b1.Len() {
+		panic(errOutOfRange)
+	}
+
+	var (
+		total uint64
+		x     uint16
+	)
+
+	if mask := mask1(start, end); mask != 0 {
+		x = uint16(op(b[start>>3], b1[start>>3]) & mask)
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		total = count(b[start>>3:end>>3], b1[start>>3:end>>3])
+	}
+
+	if mask := mask2(start, end); mask != 0 {
+		x |= uint16(op(b[end>>3], b1[end>>3])&mask) << 8
+	}
+
+	return uint(uint64(bits.OnesCount16(x)) + total)
+}
+
+func (b Bitset) IntersectionCountRange(b1 Bitset, start, end uint) uint {
+	return b.countRange(b1, start, end, bitwise.AndCount, func(x, y byte) byte {
+		return x & y
+	})
+}
+
+func (b Bitset) UnionCountRange(b1 Bitset, start, end uint) uint {
+	return b.countRange(b1, start, end, bitwise.OrCount, func(x, y byte) byte {
+		return x | y
+	})
+}
+
+func (b Bitset) DifferenceCountRange(b1 Bitset, start, end uint) uint {
+	return b.countRange(b1, start, end, bitwise.AndNotCount, func(x, y byte) byte {
+		return x &^ y
+	})
+}
+
+func (b Bitset) SymmetricDifferenceCountRange(b1 Bitset, start, end uint) uint {
+	return b.countRange(b1, start, end, bitwise.XORCount, func(x, y byte) byte {
+		return x ^ y
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+var bitwiseCountOps = []struct {
+	name       string
+	count      func(b, b1 Bitset) uint
+	countRange func(b, b1 Bitset, start, end uint) uint
+	op         func(x, y bool) bool
+}{
+	{"Intersection", Bitset.IntersectionCount, Bitset.IntersectionCountRange,
+		func(x, y bool) bool { return x && y }},
+	{"Union", Bitset.UnionCount, Bitset.UnionCountRange,
+		func(x, y bool) bool { return x || y }},
+	{"Difference", Bitset.DifferenceCount, Bitset.DifferenceCountRange,
+		func(x, y bool) bool { return x && !y }},
+	{"SymmetricDifference", Bitset.SymmetricDifferenceCount, Bitset.SymmetricDifferenceCountRange,
+		func(x, y bool) bool { return x != y }},
+}
+
+func bitwiseCountTestValues(args []reflect.Value, rand *rand.Rand) {
+	b, b1 := New(uint(rand.Intn(4096))), New(uint(rand.Intn(4096)))
+	rand.Read(b)
+	rand.Read(b1)
+
+	args[0] = reflect.ValueOf(b)
+	args[1] = reflect.ValueOf(b1)
+}
+
+func TestBitwiseCount(t *testing.T) {
+	for _, v := range bitwiseCountOps {
+		if err := quick.CheckEqual(func(b, b1 Bitset) (count uint) {
+			l := b.Len()
+			if b1.Len() > l {
+				l = b1.Len()
+			}
+
+			for i := uint(0); i < l; i++ {
+				if v.op(i < b.Len() && b.IsSet(i), i < b1.Len() && b1.IsSet(i)) {
+					count++
+				}
+			}
+
+			return
+		}, v.count, &quick.Config{
+			Values:        bitwiseCountTestValues,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%sCount failed: %v", v.name, err)
+		}
+
+		if err := quick.CheckEqual(func(b, b1 Bitset, start, end uint) (count uint) {
+			for i := start; i < end; i++ {
+				if v.op(b.IsSet(i), b1.IsSet(i)) {
+					count++
+				}
+			}
+
+			return
+		}, v.countRange, &quick.Config{
+			Values:        rangeTestValues2,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%sCountRange failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestBitwiseCountDifferentLengths(t *testing.T) {
+	b, b1 := New(16), New(32)
+	b.SetRange(0, 12)
+	b1.SetRange(8, 24)
+
+	for _, v := range []struct {
+		name            string
+		count, expected uint
+	}{
+		{"IntersectionCount", b.IntersectionCount(b1), 4},
+		{"UnionCount", b.UnionCount(b1), 24},
+		{"UnionCount", b1.UnionCount(b), 24},
+		{"DifferenceCount", b.DifferenceCount(b1), 8},
+		{"DifferenceCount", b1.DifferenceCount(b), 12},
+		{"SymmetricDifferenceCount", b.SymmetricDifferenceCount(b1), 20},
+	} {
+		if v.count != v.expected {
+			t.Errorf("%s failed, expected %d, got %d", v.name, v.expected, v.count)
+		}
+	}
+
+	// Union stops at the shorter operand, so it stores
+	// fewer bits than UnionCount counts.
+	u := New(32)
+	u.Union(b, b1)
+
+	if u.Count() != 16 {
+		t.Errorf("Union stored %d bits, expected 16", u.Count())
+	}
+}
+
+func BenchmarkIntersectionCount(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	rand.Read(b1)
+	rand.Read(b2)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.IntersectionCount(b2)
+	}
+}
+
+func BenchmarkIntersectionThenCount(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	rand.Read(b1)
+	rand.Read(b2)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b3 := New(b1.Len())
+		b3.Intersection(b1, b2)
+		b3.Count()
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Compare returns an integer comparing b and b1 as
+// unsigned little-endian integers, where bit i has the
+// value 2^i. The result is 0 if b.EqualTrimmed(b1), -1 if
+// b < b1 and +1 if b > b1.
+func (b Bitset) Compare(b1 Bitset) int {
+	b, b1 = b.Trim(), b1.Trim()
+
+	switch {
+	case len(b) < len(b1):
+		return -1
+	case len(b) > len(b1):
+		return +1
+	}
+
+	i := len(b)
+	for ; i >= 8; i -= 8 {
+		x := binary.LittleEndian.Uint64(b[i-8:])
+		y := binary.LittleEndian.Uint64(b1[i-8:])
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return +1
+		}
+	}
+
+	for ; i > 0; i-- {
+		switch {
+		case b[i-1] < b1[i-1]:
+			return -1
+		case b[i-1] > b1[i-1]:
+			return +1
+		}
+	}
+
+	return 0
+}
+
+// CompareLex returns an integer comparing b and b1 as
+// sequences of bits, in order from bit 0, where a set bit
+// is greater than a clear bit. If one Bitset is a prefix
+// of the other, the shorter is less. The result is 0 if
+// b.Equal(b1), -1 if b < b1 and +1 if b > b1.
+func (b Bitset) CompareLex(b1 Bitset) int {
+	n := len(b)
+	if len(b1) < n {
+		n = len(b1)
+	}
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(b[i:])
+		y := binary.LittleEndian.Uint64(b1[i:])
+
+		if d := x ^ y; d != 0 {
+			return compareLowest(x, bits.TrailingZeros64(d))
+		}
+	}
+
+	for ; i < n; i++ {
+		if d := b[i] ^ b1[i]; d != 0 {
+			return compareLowest(uint64(b[i]), bits.TrailingZeros8(d))
+		}
+	}
+
+	switch {
+	case len(b) < len(b1):
+		return -1
+	case len(b) > len(b1):
+		return +1
+	default:
+		return 0
+	}
+}
+
+func compareLowest(x uint64, bit int) int {
+	if x&(1<<uint(bit)) != 0 {
+		return +1
+	}
+
+	return -1
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestCompare(t *testing.T) {
+	if err := quick.CheckEqual(func(b, b1 Bitset) int {
+		toInt := func(b Bitset) *big.Int {
+			x := new(big.Int)
+			for i := uint(0); i < b.Len(); i++ {
+				if b.IsSet(i) {
+					x.SetBit(x, int(i), 1)
+				}
+			}
+
+			return x
+		}
+
+		return toInt(b).Cmp(toInt(b1))
+	}, Bitset.Compare, &quick.Config{
+		Values:        supersetTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	b, b1 := New(80), New(160)
+	b.Set(3)
+	b1.Set(3)
+
+	if b.Compare(b1) != 0 || b1.Compare(b) != 0 {
+		t.Error("Compare failed, trailing zero bytes should be ignored")
+	}
+
+	b1.Set(2)
+
+	if b.Compare(b1) != -1 || b1.Compare(b) != +1 {
+		t.Error("Compare failed")
+	}
+}
+
+func TestCompareLex(t *testing.T) {
+	toString := func(b Bitset) string {
+		var s strings.Builder
+		for i := uint(0); i < b.Len(); i++ {
+			if b.IsSet(i) {
+				s.WriteByte('1')
+			} else {
+				s.WriteByte('0')
+			}
+		}
+
+		return s.String()
+	}
+
+	if err := quick.CheckEqual(func(b, b1 Bitset) int {
+		return strings.Compare(toString(b), toString(b1))
+	}, Bitset.CompareLex, &quick.Config{
+		Values:        supersetTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	if err := quick.Check(func(b, b1 Bitset) bool {
+		if b.Len() > b1.Len() {
+			b, b1 = b1, b
+		}
+
+		b1 = b1.Clone()
+		copy(b1, b)
+
+		return b.CompareLex(b1) == -1 && b1.CompareLex(b) == +1 && b1.CompareLex(b1) == 0 ||
+			b.Len() == b1.Len()
+	}, &quick.Config{
+		Values:        supersetTestValues,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	bs := []Bitset{New(16), New(8), New(24)}
+	bs[0].Set(1)
+	bs[2].Set(0)
+
+	sort.Slice(bs, func(i, j int) bool {
+		return bs[i].CompareLex(bs[j]) < 0
+	})
+
+	if bs[0].Len() != 8 || bs[1].Len() != 16 || bs[2].Len() != 24 {
+		t.Errorf("CompareLex failed, got %v", bs)
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	b1.SetAll()
+	b2.SetAll()
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.Compare(b2)
+	}
+}
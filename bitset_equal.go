@@ -5,7 +5,11 @@
 
 package bitset
 
-import "bytes"
+import (
+	"bytes"
+
+	"github.com/tmthrgd/go-byte-test"
+)
 
 func (b Bitset) Equal(b1 Bitset) bool {
 	return bytes.Equal(b, b1)
@@ -38,3 +42,24 @@ func (b Bitset) EqualRange(b1 Bitset, start, end uint) bool {
 
 	return true
 }
+
+// Trim returns b with any trailing zero bytes removed.
+func (b Bitset) Trim() Bitset {
+	i := len(b)
+	for i > 0 && b[i-1] == 0 {
+		i--
+	}
+
+	return b[:i]
+}
+
+// EqualTrimmed is like Equal but ignores trailing zero
+// bytes, so Bitsets of different lengths are equal if the
+// longer has no bits set beyond the end of the shorter.
+func (b Bitset) EqualTrimmed(b1 Bitset) bool {
+	if len(b1) < len(b) {
+		b, b1 = b1, b
+	}
+
+	return bytes.Equal(b, b1[:len(b)]) && bytetest.Test(b1[len(b):], 0)
+}
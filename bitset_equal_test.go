@@ -26,3 +26,32 @@ func TestEqual(t *testing.T) {
 		t.Error("Equal failed")
 	}
 }
+
+func TestEqualTrimmed(t *testing.T) {
+	b, b1 := New(80), New(160)
+
+	if !b.EqualTrimmed(b1) || !b1.EqualTrimmed(b) {
+		t.Error("EqualTrimmed failed")
+	}
+
+	b.Set(10)
+	b1.Set(10)
+
+	if !b.EqualTrimmed(b1) || !b1.EqualTrimmed(b) {
+		t.Error("EqualTrimmed failed")
+	}
+
+	b1.Set(100)
+
+	if b.EqualTrimmed(b1) || b1.EqualTrimmed(b) {
+		t.Error("EqualTrimmed failed")
+	}
+
+	if l := b1.Trim().Len(); l != 104 {
+		t.Errorf("Trim failed, expected length 104, got %d", l)
+	}
+
+	if l := New(80).Trim().Len(); l != 0 {
+		t.Errorf("Trim failed, expected length 0, got %d", l)
+	}
+}
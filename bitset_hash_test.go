@@ -0,0 +1,83 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestHash64(t *testing.T) {
+	for _, v := range []struct {
+		in   string
+		hash uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+	} {
+		if h := Bitset(v.in).Hash64(0); h != v.hash {
+			t.Errorf("Hash64(%q) failed, expected %016x, got %016x", v.in, v.hash, h)
+		}
+	}
+
+	if err := quick.Check(func(b Bitset, seed uint64) bool {
+		return b.Hash64(seed) == b.Clone().Hash64(seed) &&
+			b.Hash64(seed) != b.Hash64(seed+1)
+	}, &quick.Config{
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	if err := quick.Check(func(b Bitset, seed uint32) bool {
+		h := b.Hash64(uint64(seed))
+		return b.Hash(seed) == uint32(h^h>>32)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	m := make(map[Key]int)
+
+	for i := uint(0); i < 100; i++ {
+		b := New(100)
+		b.Set(i)
+		m[b.Key()] = int(i)
+	}
+
+	b := New(100)
+	b.Set(42)
+
+	if i, ok := m[b.Key()]; !ok || i != 42 {
+		t.Error("Key failed, could not find Bitset in map")
+	}
+
+	if !b.Key().Bitset().Equal(b) {
+		t.Error("Key.Bitset failed")
+	}
+
+	if _, ok := m[New(100).Key()]; ok {
+		t.Error("Key failed, found empty Bitset in map")
+	}
+}
+
+func BenchmarkHash64(b *testing.B) {
+	b1 := New(1 << 20)
+	rand.Read(b1)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.Hash64(0)
+	}
+}
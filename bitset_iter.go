@@ -0,0 +1,65 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import "iter"
+
+// SetBits returns an iterator over the set bits of b,
+// in increasing order.
+func (b Bitset) SetBits() iter.Seq[uint] {
+	return b.SetBitsRange(0, b.Len())
+}
+
+// ClearBits returns an iterator over the clear bits
+// of b, in increasing order.
+func (b Bitset) ClearBits() iter.Seq[uint] {
+	return b.ClearBitsRange(0, b.Len())
+}
+
+// SetBitsRange returns an iterator over the set bits
+// of b in [start, end).
+func (b Bitset) SetBitsRange(start, end uint) iter.Seq[uint] {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return func(yield func(uint) bool) {
+		b.iterate(start, end, 0, yield)
+	}
+}
+
+// ClearBitsRange returns an iterator over the clear
+// bits of b in [start, end).
+func (b Bitset) ClearBitsRange(start, end uint) iter.Seq[uint] {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return func(yield func(uint) bool) {
+		b.iterate(start, end, 0xff, yield)
+	}
+}
+
+func (b Bitset) iterate(start, end uint, skip byte, yield func(uint) bool) {
+	b = b[:(end+7)>>3]
+
+	for bit, ok := b.next(start, skip); ok && bit < end; bit, ok = b.next(bit+1, skip) {
+		if !yield(bit) {
+			return
+		}
+	}
+}
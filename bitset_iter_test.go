@@ -0,0 +1,100 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import (
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+func testBits(b Bitset, start, end uint, value bool) (bits []uint) {
+	for i := start; i < end; i++ {
+		if b.IsSet(i) == value {
+			bits = append(bits, i)
+		}
+	}
+
+	return
+}
+
+func TestSetBits(t *testing.T) {
+	b := New(200)
+	b.Set(3)
+	b.Set(64)
+	b.Set(199)
+
+	if exp, got := []uint{3, 64, 199}, slices.Collect(b.SetBits()); !slices.Equal(exp, got) {
+		t.Errorf("SetBits failed, expected %v, got %v", exp, got)
+	}
+
+	for i := range b.SetBits() {
+		if i != 3 {
+			t.Errorf("SetBits failed, expected early termination after 3, got %d", i)
+		}
+
+		break
+	}
+}
+
+func TestClearBits(t *testing.T) {
+	b := New(16)
+	b.SetAll()
+	b.Clear(0)
+	b.Clear(9)
+
+	if exp, got := []uint{0, 9}, slices.Collect(b.ClearBits()); !slices.Equal(exp, got) {
+		t.Errorf("ClearBits failed, expected %v, got %v", exp, got)
+	}
+}
+
+func TestSetBitsRange(t *testing.T) {
+	if err := quick.CheckEqual(func(b, _ Bitset, start, end uint) []uint {
+		return testBits(b, start, end, true)
+	}, func(b, _ Bitset, start, end uint) []uint {
+		return slices.Collect(b.SetBitsRange(start, end))
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClearBitsRange(t *testing.T) {
+	if err := quick.CheckEqual(func(b, _ Bitset, start, end uint) []uint {
+		return testBits(b, start, end, false)
+	}, func(b, _ Bitset, start, end uint) []uint {
+		return slices.Collect(b.ClearBitsRange(start, end))
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkSetBits(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+			bs.Set(0)
+			bs.Set(bs.Len() - 1)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				for range bs.SetBits() {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/tmthrgd/go-bitset/internal/bitwise"
+)
+
+// thresholdBlock is the number of 64-bit words that
+// Threshold counts at a time. It is small enough that
+// the counters for a block stay in the L1 cache.
+const thresholdBlock = 256
+
+func byteSlices(srcs []Bitset) [][]byte {
+	s := make([][]byte, len(srcs))
+	for i, src := range srcs {
+		s[i] = src
+	}
+
+	return s
+}
+
+func minLen(b Bitset, srcs []Bitset) int {
+	n := len(b)
+	for _, src := range srcs {
+		if len(src) < n {
+			n = len(src)
+		}
+	}
+
+	return n
+}
+
+// UnionAll sets b to the union of every Bitset in srcs,
+// reading each of them only once. Like Union, it stops
+// at the end of the shortest Bitset. If srcs is empty,
+// b is cleared.
+func (b Bitset) UnionAll(srcs ...Bitset) {
+	if len(srcs) == 0 {
+		b.ClearAll()
+		return
+	}
+
+	bitwise.OrN(b, byteSlices(srcs))
+}
+
+// IntersectionAll sets b to the intersection of every
+// Bitset in srcs, reading each of them only once. Like
+// Intersection, it stops at the end of the shortest
+// Bitset. If srcs is empty, every bit in b is set.
+func (b Bitset) IntersectionAll(srcs ...Bitset) {
+	if len(srcs) == 0 {
+		b.SetAll()
+		return
+	}
+
+	bitwise.AndN(b, byteSlices(srcs))
+}
+
+// Threshold sets each bit in b that is set in at least
+// k of the Bitsets in srcs and clears every other bit.
+// Threshold(1, ...) is UnionAll and Threshold(len(srcs),
+// ...) is IntersectionAll. It stops at the end of the
+// shortest Bitset.
+func (b Bitset) Threshold(k uint, srcs ...Bitset) {
+	n := minLen(b, srcs)
+
+	switch {
+	case k == 0:
+		b[:n].SetAll()
+		return
+	case k > uint(len(srcs)):
+		b[:n].ClearAll()
+		return
+	case k == 1:
+		b.UnionAll(srcs...)
+		return
+	case k == uint(len(srcs)):
+		b.IntersectionAll(srcs...)
+		return
+	}
+
+	// The counters are bit-sliced: plane p of the
+	// counter for word w is counters[p*thresholdBlock+w].
+	planes := bits.Len(uint(len(srcs)))
+	counters := make([]uint64, planes*thresholdBlock)
+
+	for off := 0; off < n; off += thresholdBlock * 8 {
+		end := off + thresholdBlock*8
+		if end > n {
+			end = n
+		}
+
+		words := (end - off + 7) >> 3
+
+		for i := range counters {
+			counters[i] = 0
+		}
+
+		for _, src := range srcs {
+			src := src[off:end]
+
+			for w := 0; w < words; w++ {
+				x := loadWord(src, w<<3)
+
+				for p := w; x != 0; p += thresholdBlock {
+					c := counters[p]
+					counters[p] = c ^ x
+					x &= c
+				}
+			}
+		}
+
+		for w := 0; w < words; w++ {
+			gt, eq := uint64(0), ^uint64(0)
+
+			for p := planes - 1; p >= 0; p-- {
+				c := counters[p*thresholdBlock+w]
+
+				if k&(1<<uint(p)) != 0 {
+					eq &= c
+				} else {
+					gt |= eq & c
+					eq &^= c
+				}
+			}
+
+			storeWord(b[off:end], w<<3, gt|eq)
+		}
+	}
+}
+
+func loadWord(b []byte, i int) uint64 {
+	if i+8 <= len(b) {
+		return binary.LittleEndian.Uint64(b[i:])
+	}
+
+	var w [8]byte
+	copy(w[:], b[i:])
+	return binary.LittleEndian.Uint64(w[:])
+}
+
+func storeWord(b []byte, i int, x uint64) {
+	if i+8 <= len(b) {
+		binary.LittleEndian.PutUint64(b[i:], x)
+		return
+	}
+
+	var w [8]byte
+	binary.LittleEndian.PutUint64(w[:], x)
+	copy(b[i:], w[:])
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func naryTestValues(args []reflect.Value, rand *rand.Rand) {
+	size := 1 + rand.Intn(8192)
+
+	srcs := make([]Bitset, 1+rand.Intn(9))
+	for i := range srcs {
+		srcs[i] = New(uint(size))
+
+		switch rand.Intn(4) {
+		case 0:
+			srcs[i].SetAll()
+		case 1:
+		default:
+			rand.Read(srcs[i])
+		}
+	}
+
+	if rand.Intn(4) == 0 {
+		srcs[rand.Intn(len(srcs))] = New(uint(rand.Intn(size)))
+	}
+
+	args[0] = reflect.ValueOf(uint(rand.Intn(len(srcs) + 2)))
+	args[1] = reflect.ValueOf(srcs)
+}
+
+func testThreshold(k uint, srcs []Bitset) []byte {
+	b := New(uint(minLen(srcs[0], srcs)) << 3)
+
+	for i := uint(0); i < b.Len(); i++ {
+		var n uint
+		for _, src := range srcs {
+			if src.IsSet(i) {
+				n++
+			}
+		}
+
+		b.SetTo(i, n >= k)
+	}
+
+	return b
+}
+
+func TestUnionAll(t *testing.T) {
+	if err := quick.CheckEqual(func(k uint, srcs []Bitset) []byte {
+		return testThreshold(1, srcs)
+	}, func(k uint, srcs []Bitset) []byte {
+		b := New(uint(minLen(srcs[0], srcs)) << 3)
+		b.UnionAll(srcs...)
+		return b
+	}, &quick.Config{
+		Values:        naryTestValues,
+		MaxCountScale: 5,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	b := New(80)
+	b.SetAll()
+	b.UnionAll()
+
+	if !b.None() {
+		t.Error("UnionAll failed, should have cleared b")
+	}
+}
+
+func TestIntersectionAll(t *testing.T) {
+	if err := quick.CheckEqual(func(k uint, srcs []Bitset) []byte {
+		return testThreshold(uint(len(srcs)), srcs)
+	}, func(k uint, srcs []Bitset) []byte {
+		b := New(uint(minLen(srcs[0], srcs)) << 3)
+		b.IntersectionAll(srcs...)
+		return b
+	}, &quick.Config{
+		Values:        naryTestValues,
+		MaxCountScale: 5,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	b := New(80)
+	b.IntersectionAll()
+
+	if !b.All() {
+		t.Error("IntersectionAll failed, should have set b")
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	if err := quick.CheckEqual(testThreshold, func(k uint, srcs []Bitset) []byte {
+		b := New(uint(minLen(srcs[0], srcs)) << 3)
+		rand.Read(b)
+		b.Threshold(k, srcs...)
+		return b
+	}, &quick.Config{
+		Values:        naryTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestThresholdAliased(t *testing.T) {
+	srcs := make([]Bitset, 5)
+	for i := range srcs {
+		srcs[i] = New(100000)
+		rand.Read(srcs[i])
+	}
+
+	expect := testThreshold(3, srcs)
+
+	srcs[2].Threshold(3, srcs...)
+
+	if !srcs[2].Equal(expect) {
+		t.Error("Threshold failed when b is one of srcs")
+	}
+}
+
+func benchmarkNary(b *testing.B, fn func(dst Bitset, srcs []Bitset)) {
+	srcs := make([]Bitset, 32)
+	for i := range srcs {
+		srcs[i] = New(1 << 20)
+		rand.Read(srcs[i])
+	}
+
+	dst := New(1 << 20)
+
+	b.SetBytes(int64(len(dst) * len(srcs)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		fn(dst, srcs)
+	}
+}
+
+func BenchmarkUnionAll(b *testing.B) {
+	benchmarkNary(b, func(dst Bitset, srcs []Bitset) {
+		dst.UnionAll(srcs...)
+	})
+}
+
+func BenchmarkUnionPairwise(b *testing.B) {
+	benchmarkNary(b, func(dst Bitset, srcs []Bitset) {
+		dst.Union(srcs[0], srcs[1])
+
+		for _, src := range srcs[2:] {
+			dst.Union(dst, src)
+		}
+	})
+}
+
+func BenchmarkIntersectionAll(b *testing.B) {
+	benchmarkNary(b, func(dst Bitset, srcs []Bitset) {
+		dst.IntersectionAll(srcs...)
+	})
+}
+
+func BenchmarkThreshold(b *testing.B) {
+	benchmarkNary(b, func(dst Bitset, srcs []Bitset) {
+		dst.Threshold(uint(len(srcs)/2), srcs...)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+func (b Bitset) NextSet(bit uint) (uint, bool) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return b.next(bit, 0)
+}
+
+func (b Bitset) NextClear(bit uint) (uint, bool) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return b.next(bit, 0xff)
+}
+
+func (b Bitset) PrevSet(bit uint) (uint, bool) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return b.prev(bit, 0)
+}
+
+func (b Bitset) PrevClear(bit uint) (uint, bool) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	return b.prev(bit, 0xff)
+}
+
+// next returns the first bit at or after bit that
+// differs from skip, which must be either 0x00 or 0xff.
+func (b Bitset) next(bit uint, skip byte) (uint, bool) {
+	i := bit >> 3
+	if i >= uint(len(b)) {
+		return 0, false
+	}
+
+	if x := (b[i] ^ skip) >> (bit & 7); x != 0 {
+		return bit + uint(bits.TrailingZeros8(x)), true
+	}
+
+	skip64 := uint64(skip) * 0x0101010101010101
+
+	for i++; i+8 <= uint(len(b)); i += 8 {
+		if x := binary.LittleEndian.Uint64(b[i:]) ^ skip64; x != 0 {
+			return i<<3 + uint(bits.TrailingZeros64(x)), true
+		}
+	}
+
+	for ; i < uint(len(b)); i++ {
+		if x := b[i] ^ skip; x != 0 {
+			return i<<3 + uint(bits.TrailingZeros8(x)), true
+		}
+	}
+
+	return 0, false
+}
+
+// prev returns the last bit at or before bit that
+// differs from skip, which must be either 0x00 or 0xff.
+func (b Bitset) prev(bit uint, skip byte) (uint, bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+
+	if bit >= b.Len() {
+		bit = b.Len() - 1
+	}
+
+	i := bit >> 3
+
+	if x := (b[i] ^ skip) << (7 - bit&7); x != 0 {
+		return bit - uint(bits.LeadingZeros8(x)), true
+	}
+
+	skip64 := uint64(skip) * 0x0101010101010101
+
+	for ; i >= 8; i -= 8 {
+		if x := binary.LittleEndian.Uint64(b[i-8:]) ^ skip64; x != 0 {
+			return i<<3 - 1 - uint(bits.LeadingZeros64(x)), true
+		}
+	}
+
+	for i > 0 {
+		i--
+
+		if x := b[i] ^ skip; x != 0 {
+			return i<<3 + 7 - uint(bits.LeadingZeros8(x)), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,187 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func sparseTestValues(args []reflect.Value, rand *rand.Rand) {
+	size := 1 + rand.Intn(4096)
+	bit := rand.Intn(size + 1)
+
+	b := New(uint(size))
+	switch rand.Intn(3) {
+	case 0:
+		rand.Read(b)
+	case 1:
+		b.SetAll()
+		fallthrough
+	case 2:
+		for n := rand.Intn(4); n > 0; n-- {
+			b.Invert(uint(rand.Intn(size)))
+		}
+	}
+
+	args[0] = reflect.ValueOf(b)
+	args[1] = reflect.ValueOf(uint(bit))
+}
+
+func testNext(b Bitset, bit uint, value bool) (uint, bool) {
+	for ; bit < b.Len(); bit++ {
+		if b.IsSet(bit) == value {
+			return bit, true
+		}
+	}
+
+	return 0, false
+}
+
+func testPrev(b Bitset, bit uint, value bool) (uint, bool) {
+	if bit >= b.Len() {
+		bit = b.Len() - 1
+	}
+
+	for bit := int(bit); bit >= 0; bit-- {
+		if b.IsSet(uint(bit)) == value {
+			return uint(bit), true
+		}
+	}
+
+	return 0, false
+}
+
+func TestNextSet(t *testing.T) {
+	b := New(200)
+	b.Set(3)
+	b.Set(150)
+
+	for _, v := range []struct {
+		bit, next uint
+		ok        bool
+	}{
+		{0, 3, true}, {3, 3, true}, {4, 150, true},
+		{150, 150, true}, {151, 0, false}, {200, 0, false},
+	} {
+		if next, ok := b.NextSet(v.bit); next != v.next || ok != v.ok {
+			t.Errorf("NextSet(%d) failed, expected (%d, %t), got (%d, %t)", v.bit, v.next, v.ok, next, ok)
+		}
+	}
+
+	if err := quick.CheckEqual(func(b Bitset, bit uint) (uint, bool) {
+		return testNext(b, bit, true)
+	}, func(b Bitset, bit uint) (uint, bool) {
+		return b.NextSet(bit)
+	}, &quick.Config{
+		Values:        sparseTestValues,
+		MaxCountScale: 250,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNextClear(t *testing.T) {
+	if err := quick.CheckEqual(func(b Bitset, bit uint) (uint, bool) {
+		return testNext(b, bit, false)
+	}, func(b Bitset, bit uint) (uint, bool) {
+		return b.NextClear(bit)
+	}, &quick.Config{
+		Values:        sparseTestValues,
+		MaxCountScale: 250,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrevSet(t *testing.T) {
+	b := New(200)
+	b.Set(3)
+	b.Set(150)
+
+	for _, v := range []struct {
+		bit, prev uint
+		ok        bool
+	}{
+		{0, 0, false}, {2, 0, false}, {3, 3, true}, {149, 3, true},
+		{150, 150, true}, {199, 150, true}, {200, 150, true},
+	} {
+		if prev, ok := b.PrevSet(v.bit); prev != v.prev || ok != v.ok {
+			t.Errorf("PrevSet(%d) failed, expected (%d, %t), got (%d, %t)", v.bit, v.prev, v.ok, prev, ok)
+		}
+	}
+
+	if err := quick.CheckEqual(func(b Bitset, bit uint) (uint, bool) {
+		return testPrev(b, bit, true)
+	}, func(b Bitset, bit uint) (uint, bool) {
+		return b.PrevSet(bit)
+	}, &quick.Config{
+		Values:        sparseTestValues,
+		MaxCountScale: 250,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrevClear(t *testing.T) {
+	if err := quick.CheckEqual(func(b Bitset, bit uint) (uint, bool) {
+		return testPrev(b, bit, false)
+	}, func(b Bitset, bit uint) (uint, bool) {
+		return b.PrevClear(bit)
+	}, &quick.Config{
+		Values:        sparseTestValues,
+		MaxCountScale: 250,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNextSetOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NextSet did not panic for out of range bit")
+		}
+	}()
+
+	New(80).NextSet(81)
+}
+
+func BenchmarkNextSet(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+			bs.Set(bs.Len() - 1)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				var _, _ = bs.NextSet(0)
+			}
+		})
+	}
+}
+
+func BenchmarkPrevSet(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+			bs.Set(0)
+			l := bs.Len()
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				var _, _ = bs.PrevSet(l)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// BitOrder is the order in which the bits of a bit
+// string are written.
+type BitOrder int
+
+const (
+	// LSBFirst writes bit 0 first.
+	LSBFirst BitOrder = iota
+
+	// MSBFirst writes the highest bit first, as the
+	// Bitset would be written as a binary number.
+	MSBFirst
+)
+
+// Parse parses the output of FullString, or of String
+// where it has not been truncated.
+func Parse(s string) (Bitset, error) {
+	if !strings.HasPrefix(s, "Bitset{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("go-bitset: invalid Bitset string %q", s)
+	}
+
+	s = s[len("Bitset{") : len(s)-len("}")]
+	if strings.HasSuffix(s, "...") {
+		return nil, errors.New("go-bitset: cannot parse truncated Bitset string")
+	}
+
+	var b Bitset
+	if err := b.DecodeText([]byte(s), HexEncoding); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ParseBits parses a string of '0' and '1' characters
+// written in the given order. The length of the result
+// is rounded up to a multiple of 8 as with New.
+func ParseBits(s string, order BitOrder) (Bitset, error) {
+	if order != LSBFirst && order != MSBFirst {
+		return nil, errors.New("go-bitset: unknown bit order")
+	}
+
+	b := New(uint(len(s)))
+
+	for i := 0; i < len(s); i++ {
+		bit := uint(i)
+		if order == MSBFirst {
+			bit = uint(len(s) - 1 - i)
+		}
+
+		switch s[i] {
+		case '0':
+		case '1':
+			b.Set(bit)
+		default:
+			return nil, fmt.Errorf("go-bitset: invalid character %q at offset %d in bit string", s[i], i)
+		}
+	}
+
+	return b, nil
+}
+
+// ParseList parses the indices and ranges notation of
+// ListEncoding, e.g. "1,3-7,42". The result is just
+// long enough to hold the highest bit, which must be
+// less than MaxListLen.
+func ParseList(s string) (Bitset, error) {
+	var b Bitset
+	if err := b.DecodeText([]byte(s), ListEncoding); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+type flagValue struct {
+	b *Bitset
+}
+
+// FlagValue returns a flag.Value that stores into b.
+//
+// It accepts either the output of FullString or the
+// indices and ranges notation of ListEncoding. If b
+// is not empty when the flag is set, the parsed value
+// must fit within it.
+func FlagValue(b *Bitset) flag.Value {
+	return flagValue{b}
+}
+
+func (f flagValue) String() string {
+	if f.b == nil {
+		return ""
+	}
+
+	return string(f.b.EncodeText(ListEncoding))
+}
+
+func (f flagValue) Set(s string) error {
+	if strings.HasPrefix(s, "Bitset{") {
+		b, err := Parse(s)
+		if err != nil {
+			return err
+		}
+
+		return f.b.decodeBytes(b)
+	}
+
+	return f.b.DecodeText([]byte(s), ListEncoding)
+}
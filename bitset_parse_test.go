@@ -0,0 +1,121 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestParse(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		b1, err := Parse(b.FullString())
+		return err == nil && b1.Equal(b)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+
+	b := New(80)
+	b.SetRange(3, 70)
+
+	if b1, err := Parse(b.String()); err != nil || !b1.Equal(b) {
+		t.Errorf("Parse failed, expected %s, got %s (%v)", b, b1, err)
+	}
+
+	for _, s := range []string{
+		"", "Bitset", "Bitset{", "bitset{00}", "Bitset{0}", "Bitset{zz}",
+		make(Bitset, 256).String(),
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse did not fail for %q", s)
+		}
+	}
+}
+
+func TestParseBits(t *testing.T) {
+	b := New(10)
+	b.Set(1)
+	b.Set(8)
+
+	for _, v := range []struct {
+		s     string
+		order BitOrder
+	}{
+		{"0100000010", LSBFirst},
+		{"0100000010", MSBFirst},
+	} {
+		b1, err := ParseBits(v.s, v.order)
+		if err != nil {
+			t.Errorf("ParseBits(%q, %d) failed: %v", v.s, v.order, err)
+		} else if !b1.Equal(b) {
+			t.Errorf("ParseBits(%q, %d) failed, expected %s, got %s", v.s, v.order, b, b1)
+		}
+	}
+
+	if b1, _ := ParseBits("1", MSBFirst); b1.FullString() != "Bitset{01}" {
+		t.Errorf("ParseBits failed, expected Bitset{01}, got %s", b1)
+	}
+
+	if _, err := ParseBits("0120", LSBFirst); err == nil || !strings.Contains(err.Error(), "offset 2") {
+		t.Errorf("ParseBits did not fail for invalid character, got %v", err)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	b, err := ParseList("1,3-7,42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := "Bitset{fa0000000004}"; b.FullString() != exp {
+		t.Errorf("ParseList failed, expected %s, got %s", exp, b)
+	}
+
+	if _, err := ParseList("3-"); err == nil {
+		t.Error("ParseList did not fail for invalid range")
+	}
+
+	for _, s := range []string{"9223372036854775807", "18446744073709551614", "1,268435456"} {
+		if _, err := ParseList(s); err == nil {
+			t.Errorf("ParseList did not fail for index %s", s)
+		}
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	var b, b1 Bitset
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(FlagValue(&b), "list", "")
+	fs.Var(FlagValue(&b1), "string", "")
+
+	if err := fs.Parse([]string{"-list", "1,3-7,42", "-string", "Bitset{0f00}"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := "1,3-7,42"; fs.Lookup("list").Value.String() != exp {
+		t.Errorf("FlagValue failed, expected %s, got %s", exp, fs.Lookup("list").Value)
+	}
+
+	if exp := "Bitset{0f00}"; b1.FullString() != exp {
+		t.Errorf("FlagValue failed, expected %s, got %s", exp, b1)
+	}
+
+	fs.SetOutput(new(strings.Builder))
+
+	if err := fs.Parse([]string{"-list", "1-x"}); err == nil {
+		t.Error("FlagValue did not fail for invalid range")
+	}
+
+	var b2 Bitset
+	fs.Var(FlagValue(&b2), "large", "")
+
+	if err := fs.Parse([]string{"-large", "9223372036854775807"}); err == nil {
+		t.Error("FlagValue did not fail for out of range index")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// RotateLeft sets b to b1 rotated left by n bits. b may
+// be b1, or overlap it, in which case b1 is copied
+// first unless they start at the same byte.
+func (b Bitset) RotateLeft(b1 Bitset, n uint) {
+	l := b1.Len()
+	if b.Len() < l {
+		panic(errOutOfRange)
+	}
+
+	if l == 0 {
+		return
+	}
+
+	n %= l
+
+	if &b[0] == &b1[0] {
+		b.rotateRange(0, l, n)
+		return
+	}
+
+	if overlaps(b, b1) {
+		b1 = b1.Clone()
+	}
+
+	copyBits(b, 0, b1, n, l-n)
+	copyBits(b, l-n, b1, 0, n)
+}
+
+// RotateRight sets b to b1 rotated right by n bits. It
+// has the same overlap rules as RotateLeft.
+func (b Bitset) RotateRight(b1 Bitset, n uint) {
+	if l := b1.Len(); l != 0 {
+		b.RotateLeft(b1, l-n%l)
+	}
+}
+
+// Rotate rotates b in place. It rotates left, as
+// RotateLeft does, for positive n and right for
+// negative n.
+func (b Bitset) Rotate(n int) {
+	b.RotateRange(0, b.Len(), n)
+}
+
+// RotateRange rotates the bits in [start, end) in place.
+// Bits outside the range are unchanged. It rotates left
+// for positive n and right for negative n.
+func (b Bitset) RotateRange(start, end uint, n int) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	l := end - start
+	if l == 0 {
+		return
+	}
+
+	b.rotateRange(start, end, uint(n%int(l)+int(l))%l)
+}
+
+// rotateRange rotates [start, end) left by k, which must
+// be less than end-start, by reversing [start, start+k),
+// then [start+k, end), then the whole range.
+func (b Bitset) rotateRange(start, end, k uint) {
+	if k == 0 {
+		return
+	}
+
+	b.reverseRange(start, start+k)
+	b.reverseRange(start+k, end)
+	b.reverseRange(start, end)
+}
+
+// reverseRange reverses the order of the bits in
+// [start, end), swapping 64 bits from each end at a
+// time.
+func (b Bitset) reverseRange(start, end uint) {
+	for ; end-start >= 128; start, end = start+64, end-64 {
+		lo, hi := b.GetBits(start, 64), b.GetBits(end-64, 64)
+		b.PutBits(start, 64, bits.Reverse64(hi))
+		b.PutBits(end-64, 64, bits.Reverse64(lo))
+	}
+
+	n1 := (end - start) / 2
+	n2 := end - start - n1
+
+	lo, hi := b.GetBits(start, n1), b.GetBits(start+n1, n2)
+	b.PutBits(start, n2, bits.Reverse64(hi)>>(64-n2))
+	b.PutBits(start+n2, n1, bits.Reverse64(lo)>>(64-n1))
+}
+
+// copyBits copies n bits from b1 starting at off1 into
+// b starting at off. b and b1 must not overlap.
+func copyBits(b Bitset, off uint, b1 Bitset, off1, n uint) {
+	for ; n >= 64; off, off1, n = off+64, off1+64, n-64 {
+		b.PutBits(off, 64, b1.GetBits(off1, 64))
+	}
+
+	b.PutBits(off, n, b1.GetBits(off1, n))
+}
+
+// overlaps reports whether b and b1 share any memory.
+func overlaps(b, b1 Bitset) bool {
+	if len(b) == 0 || len(b1) == 0 {
+		return false
+	}
+
+	p, p1 := uintptr(unsafe.Pointer(&b[0])), uintptr(unsafe.Pointer(&b1[0]))
+	return p < p1+uintptr(len(b1)) && p1 < p+uintptr(len(b))
+}
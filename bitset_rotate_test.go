@@ -0,0 +1,178 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func testRotateRange(b Bitset, start, end uint, n int) Bitset {
+	b1 := b.Clone()
+
+	l := int(end - start)
+	for i := 0; i < l; i++ {
+		j := ((i+n)%l + l) % l
+		b1.SetTo(start+uint(i), b.IsSet(start+uint(j)))
+	}
+
+	return b1
+}
+
+func TestRotateLeft(t *testing.T) {
+	b := New(16)
+	b.Set(0)
+	b.Set(9)
+
+	b1 := New(16)
+	b1.RotateLeft(b, 3)
+
+	if exp := "Bitset{4020}"; b1.String() != exp {
+		t.Errorf("RotateLeft failed, expected %s, got %s", exp, b1)
+	}
+
+	b.RotateLeft(b, 3)
+
+	if !b.Equal(b1) {
+		t.Errorf("RotateLeft failed in place, expected %s, got %s", b1, b)
+	}
+
+	if err := quick.CheckEqual(func(b, _ Bitset, n, _ uint) []byte {
+		return testRotateRange(b, 0, b.Len(), int(n))
+	}, func(b, _ Bitset, n, _ uint) []byte {
+		b1 := New(b.Len())
+		b1.RotateLeft(b, n)
+		return b1
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotateRight(t *testing.T) {
+	if err := quick.CheckEqual(func(b, _ Bitset, n, _ uint) []byte {
+		return testRotateRange(b, 0, b.Len(), -int(n))
+	}, func(b, _ Bitset, n, _ uint) []byte {
+		b = b.Clone()
+		b.RotateRight(b, n)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	b := New(80)
+	b.SetRange(0, 10)
+
+	b.Rotate(-75)
+
+	if !b.IsRangeSet(0, 5) || !b.IsRangeClear(5, 75) || !b.IsRangeSet(75, 80) {
+		t.Errorf("Rotate failed, got %s", b)
+	}
+
+	b.Rotate(75)
+
+	if !b.IsRangeSet(0, 10) || !b.IsRangeClear(10, 80) {
+		t.Errorf("Rotate failed, got %s", b)
+	}
+}
+
+func TestRotateRange(t *testing.T) {
+	if err := quick.CheckEqual(func(b, b1 Bitset, start, end uint) []byte {
+		return testRotateRange(b, start, end, int(b1[0])-128)
+	}, func(b, b1 Bitset, start, end uint) []byte {
+		b = b.Clone()
+		b.RotateRange(start, end, int(b1[0])-128)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotateInPlace(t *testing.T) {
+	if err := quick.CheckEqual(func(b, _ Bitset, n, _ uint) []byte {
+		return testRotateRange(b, 0, b.Len(), int(n))
+	}, func(b, _ Bitset, n, _ uint) []byte {
+		b = b.Clone()
+		b.RotateLeft(b, n)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	b, b1 := New(4096), New(4096)
+	rand.Read(b)
+
+	if n := testing.AllocsPerRun(100, func() {
+		b.RotateRange(3, 4000, 1234)
+		b.RotateLeft(b, 77)
+		b1.RotateLeft(b, 901)
+	}); n != 0 {
+		t.Errorf("Rotate allocated %v times, expected 0", n)
+	}
+}
+
+func TestRotateOverlapping(t *testing.T) {
+	for _, v := range []struct{ off, off1 int }{
+		{0, 1}, {1, 0}, {0, 7}, {5, 2}, {3, 3},
+	} {
+		for _, n := range []uint{0, 1, 9, 64, 100, 255} {
+			buf := New(512)
+			rand.Read(buf)
+
+			b, b1 := buf[v.off:v.off+32], buf[v.off1:v.off1+32]
+			expected := testRotateRange(b1.Clone(), 0, b1.Len(), int(n))
+
+			b.RotateLeft(b1, n)
+
+			if !b.Equal(expected) {
+				t.Errorf("RotateLeft failed for overlapping operands at offsets %d and %d by %d", v.off, v.off1, n)
+			}
+
+			rand.Read(buf)
+			expected = testRotateRange(b1.Clone(), 0, b1.Len(), -int(n))
+
+			b.RotateRight(b1, n)
+
+			if !b.Equal(expected) {
+				t.Errorf("RotateRight failed for overlapping operands at offsets %d and %d by %d", v.off, v.off1, n)
+			}
+		}
+	}
+
+	if overlaps(New(8), New(8)) {
+		t.Error("overlaps reported distinct Bitsets as overlapping")
+	}
+}
+
+func BenchmarkRotateLeft(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs, bs1 := make(Bitset, size.l), make(Bitset, size.l)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				bs.RotateLeft(bs1, 1)
+			}
+		})
+	}
+}
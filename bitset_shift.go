@@ -5,6 +5,8 @@
 
 package bitset
 
+import "encoding/binary"
+
 var useShiftFastPath = true // for testing
 
 func (b Bitset) ShiftLeft(b1 Bitset, shift uint) {
@@ -12,10 +14,8 @@ func (b Bitset) ShiftLeft(b1 Bitset, shift uint) {
 		panic(errOutOfRange)
 	}
 
-	if shift&7 == 0 && useShiftFastPath {
-		// fast path
-		copy(b, b1[shift>>3:])
-	} else {
+	switch {
+	case !useShiftFastPath:
 		// slow path
 		l := b1.Len() - shift
 		if b.Len() < l {
@@ -25,6 +25,11 @@ func (b Bitset) ShiftLeft(b1 Bitset, shift uint) {
 		for i := uint(0); i < l; i++ {
 			b.SetTo(i, b1.IsSet(i+shift))
 		}
+	case shift&7 == 0:
+		// fast path
+		copy(b, b1[shift>>3:])
+	default:
+		shiftLeftWords(b, b1, shift)
 	}
 }
 
@@ -33,18 +38,116 @@ func (b Bitset) ShiftRight(b1 Bitset, shift uint) {
 		panic(errOutOfRange)
 	}
 
-	if shift&7 == 0 && useShiftFastPath {
-		// fast path
-		copy(b[shift>>3:], b1)
-	} else {
+	switch {
+	case !useShiftFastPath:
 		// slow path
 		l := b.Len()
 		if b1.Len() < l-shift {
 			l = b1.Len() + shift
 		}
 
-		for i := l - 1; i >= shift; i-- {
-			b.SetTo(i, b1.IsSet(i-shift))
+		for i := l; i > shift; i-- {
+			b.SetTo(i-1, b1.IsSet(i-1-shift))
 		}
+	case shift&7 == 0:
+		// fast path
+		copy(b[shift>>3:], b1)
+	default:
+		shiftRightWords(b, b1, shift)
+	}
+}
+
+// shiftLeftWords implements ShiftLeft for shifts that
+// are not a multiple of 8. It works forwards through b
+// so that b and b1 may be the same Bitset.
+func shiftLeftWords(b, b1 Bitset, shift uint) {
+	l := b1.Len() - shift
+	if b.Len() < l {
+		l = b.Len()
 	}
+
+	q, r := int(shift>>3), shift&7
+	n := int(l >> 3)
+
+	j := 0
+	for ; j+8 <= n && j+q+9 <= len(b1); j += 8 {
+		w := binary.LittleEndian.Uint64(b1[j+q:])>>r | uint64(b1[j+q+8])<<(64-r)
+		binary.LittleEndian.PutUint64(b[j:], w)
+	}
+
+	for ; j < n; j++ {
+		b[j] = shiftLeftByte(b1, j+q, r)
+	}
+
+	if l&7 != 0 {
+		mask := byte(1)<<(l&7) - 1
+		b[j] = b[j]&^mask | shiftLeftByte(b1, j+q, r)&mask
+	}
+}
+
+func shiftLeftByte(b1 Bitset, i int, r uint) byte {
+	x := b1[i] >> r
+	if i+1 < len(b1) {
+		x |= b1[i+1] << (8 - r)
+	}
+
+	return x
+}
+
+// shiftRightWords implements ShiftRight for shifts that
+// are not a multiple of 8. It works backwards through b
+// so that b and b1 may be the same Bitset.
+func shiftRightWords(b, b1 Bitset, shift uint) {
+	l := b.Len()
+	if b1.Len() < l-shift {
+		l = b1.Len() + shift
+	}
+
+	if l <= shift {
+		return
+	}
+
+	q, r := int(shift>>3), shift&7
+	first, last := q, int((l-1)>>3)
+
+	mask := ^byte(0)
+	if l&7 != 0 {
+		mask = byte(1)<<(l&7) - 1
+	}
+
+	if first == last {
+		mask &= ^byte(0) << r
+	}
+
+	b[last] = b[last]&^mask | shiftRightByte(b1, last-q, r)&mask
+
+	if first == last {
+		return
+	}
+
+	j := last
+	for ; j-8 > first && j-q <= len(b1); j -= 8 {
+		w := binary.LittleEndian.Uint64(b1[j-q-8:])<<r | uint64(b1[j-q-9])>>(8-r)
+		binary.LittleEndian.PutUint64(b[j-8:], w)
+	}
+
+	for j--; j > first; j-- {
+		b[j] = shiftRightByte(b1, j-q, r)
+	}
+
+	mask = ^byte(0) << r
+	b[first] = b[first]&^mask | shiftRightByte(b1, 0, r)&mask
+}
+
+func shiftRightByte(b1 Bitset, i int, r uint) byte {
+	var x byte
+	if i < len(b1) {
+		x = b1[i] << r
+	}
+
+	if i > 0 {
+		x |= b1[i-1] >> (8 - r)
+	}
+
+	return x
 }
@@ -182,3 +182,138 @@ func TestShiftRight2(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func rangeTestShiftValues2(args []reflect.Value, rand *rand.Rand) {
+	size := 1 + rand.Intn(4096)
+	size1 := 1 + rand.Intn(4096)
+	shift := rand.Intn(size + 1)
+
+	b, b1 := New(uint(size)), New(uint(size1))
+	rand.Read(b)
+	rand.Read(b1)
+
+	args[0] = reflect.ValueOf(b)
+	args[1] = reflect.ValueOf(b1)
+	args[2] = reflect.ValueOf(uint(shift))
+}
+
+func testShiftSlowPath(fn func()) {
+	useShiftFastPath = false
+	defer func() {
+		useShiftFastPath = true
+	}()
+
+	fn()
+}
+
+func TestShiftLeftWords(t *testing.T) {
+	if err := quick.CheckEqual(func(b, b1 Bitset, shift uint) []byte {
+		b = b.Clone()
+
+		if shift <= b1.Len() {
+			testShiftSlowPath(func() {
+				b.ShiftLeft(b1, shift)
+			})
+		}
+
+		return b
+	}, func(b, b1 Bitset, shift uint) []byte {
+		b = b.Clone()
+
+		if shift <= b1.Len() {
+			b.ShiftLeft(b1, shift)
+		}
+
+		return b
+	}, &quick.Config{
+		Values:        rangeTestShiftValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	if err := quick.CheckEqual(func(b, _ Bitset, shift uint) []byte {
+		b = b.Clone()
+		testShiftSlowPath(func() {
+			b.ShiftLeft(b, shift)
+		})
+		return b
+	}, func(b, _ Bitset, shift uint) []byte {
+		b = b.Clone()
+		b.ShiftLeft(b, shift)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestShiftValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestShiftRightWords(t *testing.T) {
+	if err := quick.CheckEqual(func(b, b1 Bitset, shift uint) []byte {
+		b = b.Clone()
+		testShiftSlowPath(func() {
+			b.ShiftRight(b1, shift)
+		})
+		return b
+	}, func(b, b1 Bitset, shift uint) []byte {
+		b = b.Clone()
+		b.ShiftRight(b1, shift)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestShiftValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	if err := quick.CheckEqual(func(b, _ Bitset, shift uint) []byte {
+		b = b.Clone()
+		testShiftSlowPath(func() {
+			b.ShiftRight(b, shift)
+		})
+		return b
+	}, func(b, _ Bitset, shift uint) []byte {
+		b = b.Clone()
+		b.ShiftRight(b, shift)
+		return b
+	}, &quick.Config{
+		Values:        rangeTestShiftValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkShiftLeft(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				bs.ShiftLeft(bs, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkShiftRight(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			bs := make(Bitset, size.l)
+
+			if size.l > 1024 {
+				b.ResetTimer()
+			}
+
+			for i := 0; i < b.N; i++ {
+				bs.ShiftRight(bs, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/tmthrgd/go-bitset/internal/bitwise"
+	"github.com/tmthrgd/go-popcount"
+)
+
+var errNegativeWeight = errors.New("go-bitset: negative Tversky weight")
+
+// similarityCounts returns |b|, |b1| and |b ∩ b1|. If b
+// and b1 differ in length, the shorter is treated as
+// though it were padded with clear bits.
+func (b Bitset) similarityCounts(b1 Bitset) (n, n1, both uint64) {
+	l := len(b)
+	if len(b1) < l {
+		l = len(b1)
+	}
+
+	n, n1, both = bitwise.AndCounts(b, b1)
+	return n + popcount.CountBytes(b[l:]), n1 + popcount.CountBytes(b1[l:]), both
+}
+
+func (b Bitset) similarityCountsRange(b1 Bitset, start, end uint) (n, n1, both uint64) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() || end > b1.Len() {
+		panic(errOutOfRange)
+	}
+
+	var x, x1 uint16
+
+	if mask := mask1(start, end); mask != 0 {
+		x, x1 = uint16(b[start>>3]&mask), uint16(b1[start>>3]&mask)
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		n, n1, both = bitwise.AndCounts(b[start>>3:end>>3], b1[start>>3:end>>3])
+	}
+
+	if mask := mask2(start, end); mask != 0 {
+		x |= uint16(b[end>>3]&mask) << 8
+		x1 |= uint16(b1[end>>3]&mask) << 8
+	}
+
+	n += uint64(bits.OnesCount16(x))
+	n1 += uint64(bits.OnesCount16(x1))
+	both += uint64(bits.OnesCount16(x & x1))
+	return
+}
+
+// ratio returns num/den. When den is zero, it returns 1
+// if both sets are empty, and so equal, and 0 otherwise.
+func ratio(num, den float64, n, n1 uint64) float64 {
+	if den == 0 {
+		if n == 0 && n1 == 0 {
+			return 1
+		}
+
+		return 0
+	}
+
+	return num / den
+}
+
+func jaccard(n, n1, both uint64) float64 {
+	return ratio(float64(both), float64(n+n1-both), n, n1)
+}
+
+func dice(n, n1, both uint64) float64 {
+	return ratio(2*float64(both), float64(n)+float64(n1), n, n1)
+}
+
+func cosine(n, n1, both uint64) float64 {
+	return ratio(float64(both), math.Sqrt(float64(n)*float64(n1)), n, n1)
+}
+
+func tversky(n, n1, both uint64, alpha, beta float64) float64 {
+	if alpha < 0 || beta < 0 {
+		panic(errNegativeWeight)
+	}
+
+	den := float64(both) + alpha*float64(n-both) + beta*float64(n1-both)
+	return ratio(float64(both), den, n, n1)
+}
+
+// Jaccard returns the Jaccard index of b and b1,
+// |b ∩ b1| / |b ∪ b1|.
+//
+// Each of the similarity measures returns a value between
+// 0 and 1. Two empty sets are identical and have a
+// similarity of 1. An empty set and a non-empty set have a
+// similarity of 0. If b and b1 differ in length, the
+// shorter is treated as though it were padded with clear
+// bits.
+func (b Bitset) Jaccard(b1 Bitset) float64 {
+	return jaccard(b.similarityCounts(b1))
+}
+
+func (b Bitset) JaccardRange(b1 Bitset, start, end uint) float64 {
+	return jaccard(b.similarityCountsRange(b1, start, end))
+}
+
+// Dice returns the Sørensen–Dice coefficient of b and b1,
+// 2|b ∩ b1| / (|b| + |b1|).
+func (b Bitset) Dice(b1 Bitset) float64 {
+	return dice(b.similarityCounts(b1))
+}
+
+func (b Bitset) DiceRange(b1 Bitset, start, end uint) float64 {
+	return dice(b.similarityCountsRange(b1, start, end))
+}
+
+// Cosine returns the cosine similarity, or Ochiai
+// coefficient, of b and b1, |b ∩ b1| / √(|b| × |b1|).
+func (b Bitset) Cosine(b1 Bitset) float64 {
+	return cosine(b.similarityCounts(b1))
+}
+
+func (b Bitset) CosineRange(b1 Bitset, start, end uint) float64 {
+	return cosine(b.similarityCountsRange(b1, start, end))
+}
+
+// Tversky returns the Tversky index of b and b1,
+// |b ∩ b1| / (|b ∩ b1| + α|b \ b1| + β|b1 \ b|). With
+// α = β = 1 it is the Jaccard index and with α = β = 0.5
+// it is the Sørensen–Dice coefficient. It panics if alpha
+// or beta is negative.
+func (b Bitset) Tversky(b1 Bitset, alpha, beta float64) float64 {
+	n, n1, both := b.similarityCounts(b1)
+	return tversky(n, n1, both, alpha, beta)
+}
+
+func (b Bitset) TverskyRange(b1 Bitset, alpha, beta float64, start, end uint) float64 {
+	n, n1, both := b.similarityCountsRange(b1, start, end)
+	return tversky(n, n1, both, alpha, beta)
+}
+
+// Hamming returns the Hamming distance between b and b1,
+// the number of bits that differ between them.
+func (b Bitset) Hamming(b1 Bitset) uint {
+	return b.SymmetricDifferenceCount(b1)
+}
+
+func (b Bitset) HammingRange(b1 Bitset, start, end uint) uint {
+	return b.SymmetricDifferenceCountRange(b1, start, end)
+}
@@ -0,0 +1,163 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func testSimilarityCounts(b, b1 Bitset, start, end uint) (n, n1, both float64) {
+	for i := start; i < end; i++ {
+		x := i < b.Len() && b.IsSet(i)
+		y := i < b1.Len() && b1.IsSet(i)
+
+		if x {
+			n++
+		}
+
+		if y {
+			n1++
+		}
+
+		if x && y {
+			both++
+		}
+	}
+
+	return
+}
+
+func TestSimilarity(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(b, b1 Bitset) float64
+		fnR  func(b, b1 Bitset, start, end uint) float64
+		test func(n, n1, both float64) float64
+	}{
+		{"Jaccard", Bitset.Jaccard, Bitset.JaccardRange, func(n, n1, both float64) float64 {
+			return both / (n + n1 - both)
+		}},
+		{"Dice", Bitset.Dice, Bitset.DiceRange, func(n, n1, both float64) float64 {
+			return 2 * both / (n + n1)
+		}},
+		{"Cosine", Bitset.Cosine, Bitset.CosineRange, func(n, n1, both float64) float64 {
+			return both / math.Sqrt(n*n1)
+		}},
+		{"Tversky", func(b, b1 Bitset) float64 {
+			return b.Tversky(b1, 0.25, 2)
+		}, func(b, b1 Bitset, start, end uint) float64 {
+			return b.TverskyRange(b1, 0.25, 2, start, end)
+		}, func(n, n1, both float64) float64 {
+			return both / (both + 0.25*(n-both) + 2*(n1-both))
+		}},
+	} {
+		test := func(n, n1, both float64) float64 {
+			switch {
+			case n == 0 && n1 == 0:
+				return 1
+			case n == 0 || n1 == 0:
+				return 0
+			default:
+				return v.test(n, n1, both)
+			}
+		}
+
+		approx := func(x, y float64) bool {
+			return math.Abs(x-y) < 1e-9
+		}
+
+		if err := quick.Check(func(b, b1 Bitset) bool {
+			l := b.Len()
+			if b1.Len() > l {
+				l = b1.Len()
+			}
+
+			return approx(v.fn(b, b1), test(testSimilarityCounts(b, b1, 0, l)))
+		}, &quick.Config{
+			Values:        bitwiseCountTestValues,
+			MaxCountScale: 50,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+
+		if err := quick.Check(func(b, b1 Bitset, start, end uint) bool {
+			return approx(v.fnR(b, b1, start, end), test(testSimilarityCounts(b, b1, start, end)))
+		}, &quick.Config{
+			Values:        rangeTestValues2,
+			MaxCountScale: 50,
+		}); err != nil {
+			t.Errorf("%sRange failed: %v", v.name, err)
+		}
+
+		b, b1 := New(80), New(80)
+
+		if s := v.fn(b, b1); s != 1 {
+			t.Errorf("%s failed, expected 1 for empty sets, got %f", v.name, s)
+		}
+
+		b1.Set(3)
+
+		if s := v.fn(b, b1); s != 0 {
+			t.Errorf("%s failed, expected 0 for an empty and a non-empty set, got %f", v.name, s)
+		}
+
+		if s := v.fn(b1, b1); s != 1 {
+			t.Errorf("%s failed, expected 1 for equal sets, got %f", v.name, s)
+		}
+	}
+}
+
+func TestTversky(t *testing.T) {
+	b, b1 := New(800), New(800)
+	rand.Read(b)
+	rand.Read(b1)
+
+	if b.Tversky(b1, 1, 1) != b.Jaccard(b1) {
+		t.Error("Tversky failed, α = β = 1 should be Jaccard")
+	}
+
+	if math.Abs(b.Tversky(b1, 0.5, 0.5)-b.Dice(b1)) > 1e-9 {
+		t.Error("Tversky failed, α = β = 0.5 should be Dice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Tversky did not panic with negative weight")
+		}
+	}()
+
+	b.Tversky(b1, -1, 1)
+}
+
+func TestHamming(t *testing.T) {
+	b, b1 := New(80), New(96)
+	b.SetRange(0, 20)
+	b1.SetRange(10, 96)
+
+	if d := b.Hamming(b1); d != 10+76 {
+		t.Errorf("Hamming failed, expected 86, got %d", d)
+	}
+
+	if d := b.HammingRange(b1, 5, 15); d != 5 {
+		t.Errorf("HammingRange failed, expected 5, got %d", d)
+	}
+}
+
+func BenchmarkJaccard(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	rand.Read(b1)
+	rand.Read(b2)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.Jaccard(b2)
+	}
+}
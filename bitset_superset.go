@@ -9,12 +9,121 @@
 
 package bitset
 
-import "github.com/tmthrgd/go-bitset/internal/bitwise"
+import (
+	"bytes"
+
+	"github.com/tmthrgd/go-bitset/internal/bitwise"
+	"github.com/tmthrgd/go-byte-test"
+	"github.com/tmthrgd/go-popcount"
+)
 
 func (b Bitset) IsSuperSet(b1 Bitset) bool {
 	return bitwise.AndEq(b, b1)
 }
 
 func (b Bitset) IsStrictSuperSet(b1 Bitset) bool {
-	return b.IsSuperSet(b1) && b.Count() > b1.Count()
+	if !b.IsSuperSet(b1) {
+		return false
+	}
+
+	if len(b) >= len(b1) {
+		n := len(b1)
+		return !bytes.Equal(b[:n], b1) || !bytetest.Test(b[n:], 0)
+	}
+
+	// b1 is longer than b, so b may only be a strict
+	// superset if it has more bits set in the common
+	// prefix than b1 has set beyond it. As b is a
+	// superset of b1 within the prefix, the bits set in
+	// b but not in b1 are exactly b XOR b1.
+	n := len(b)
+	return bitwise.XORCount(b, b1) > popcount.CountBytes(b1[n:])
+}
+
+func (b Bitset) IsSubSet(b1 Bitset) bool {
+	return b1.IsSuperSet(b)
+}
+
+func (b Bitset) IsStrictSubSet(b1 Bitset) bool {
+	return b1.IsStrictSuperSet(b)
+}
+
+func (b Bitset) Intersects(b1 Bitset) bool {
+	return bitwise.AndAny(b, b1)
+}
+
+func (b Bitset) IsDisjoint(b1 Bitset) bool {
+	return !b.Intersects(b1)
+}
+
+func (b Bitset) IsSuperSetRange(b1 Bitset, start, end uint) bool {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() || end > b1.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := mask1(start, end); mask != 0 {
+		if b[start>>3]&b1[start>>3]&mask != b1[start>>3]&mask {
+			return false
+		}
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		if !bitwise.AndEq(b[start>>3:end>>3], b1[start>>3:end>>3]) {
+			return false
+		}
+	}
+
+	if mask := mask2(start, end); mask != 0 {
+		return b[end>>3]&b1[end>>3]&mask == b1[end>>3]&mask
+	}
+
+	return true
+}
+
+func (b Bitset) IsStrictSuperSetRange(b1 Bitset, start, end uint) bool {
+	return b.IsSuperSetRange(b1, start, end) && !b.EqualRange(b1, start, end)
+}
+
+func (b Bitset) IsSubSetRange(b1 Bitset, start, end uint) bool {
+	return b1.IsSuperSetRange(b, start, end)
+}
+
+func (b Bitset) IsStrictSubSetRange(b1 Bitset, start, end uint) bool {
+	return b1.IsStrictSuperSetRange(b, start, end)
+}
+
+func (b Bitset) IntersectsRange(b1 Bitset, start, end uint) bool {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() || end > b1.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := mask1(start, end); mask != 0 {
+		if b[start>>3]&b1[start>>3]&mask != 0 {
+			return true
+		}
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		if bitwise.AndAny(b[start>>3:end>>3], b1[start>>3:end>>3]) {
+			return true
+		}
+	}
+
+	if mask := mask2(start, end); mask != 0 {
+		return b[end>>3]&b1[end>>3]&mask != 0
+	}
+
+	return false
+}
+
+func (b Bitset) IsDisjointRange(b1 Bitset, start, end uint) bool {
+	return !b.IntersectsRange(b1, start, end)
 }
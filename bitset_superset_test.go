@@ -9,7 +9,12 @@
 
 package bitset
 
-import "testing"
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
 
 func TestIsSuperSet(t *testing.T) {
 	a := New(500)
@@ -72,3 +77,154 @@ func TestIsSuperSet(t *testing.T) {
 		t.Errorf("IsStrictSuperSet fails")
 	}
 }
+
+func supersetTestValues(args []reflect.Value, rand *rand.Rand) {
+	b, b1 := New(uint(rand.Intn(4096))), New(uint(rand.Intn(4096)))
+	rand.Read(b)
+	rand.Read(b1)
+
+	switch rand.Intn(4) {
+	case 0:
+		b1.Intersection(b1, b)
+	case 1:
+		b.Union(b, b1)
+	case 2:
+		b1.Difference(b1, b)
+	}
+
+	args[0] = reflect.ValueOf(b)
+	args[1] = reflect.ValueOf(b1)
+}
+
+func rangeTestSupersetValues(args []reflect.Value, rand *rand.Rand) {
+	rangeTestValues2(args, rand)
+
+	b, b1 := args[0].Interface().(Bitset), args[1].Interface().(Bitset)
+
+	switch rand.Intn(4) {
+	case 0:
+		b1.Intersection(b1, b)
+	case 1:
+		b.Union(b, b1)
+	case 2:
+		b1.Difference(b1, b)
+	}
+}
+
+func TestSetPredicates(t *testing.T) {
+	minLen := func(b, b1 Bitset) uint {
+		if b1.Len() < b.Len() {
+			return b1.Len()
+		}
+
+		return b.Len()
+	}
+
+	superset := func(b, b1 Bitset, start, end uint) bool {
+		for i := start; i < end; i++ {
+			if b1.IsSet(i) && !b.IsSet(i) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	intersects := func(b, b1 Bitset, start, end uint) bool {
+		for i := start; i < end; i++ {
+			if b.IsSet(i) && b1.IsSet(i) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, v := range []struct {
+		name string
+		fn   func(b, b1 Bitset) bool
+		test func(b, b1 Bitset) bool
+	}{
+		{"IsSuperSet", Bitset.IsSuperSet, func(b, b1 Bitset) bool {
+			return superset(b, b1, 0, minLen(b, b1))
+		}},
+		{"IsStrictSuperSet", Bitset.IsStrictSuperSet, func(b, b1 Bitset) bool {
+			return superset(b, b1, 0, minLen(b, b1)) && b.Count() > b1.Count()
+		}},
+		{"IsSubSet", Bitset.IsSubSet, func(b, b1 Bitset) bool {
+			return superset(b1, b, 0, minLen(b, b1))
+		}},
+		{"IsStrictSubSet", Bitset.IsStrictSubSet, func(b, b1 Bitset) bool {
+			return superset(b1, b, 0, minLen(b, b1)) && b1.Count() > b.Count()
+		}},
+		{"Intersects", Bitset.Intersects, func(b, b1 Bitset) bool {
+			return intersects(b, b1, 0, minLen(b, b1))
+		}},
+		{"IsDisjoint", Bitset.IsDisjoint, func(b, b1 Bitset) bool {
+			return !intersects(b, b1, 0, minLen(b, b1))
+		}},
+	} {
+		if err := quick.CheckEqual(v.test, v.fn, &quick.Config{
+			Values:        supersetTestValues,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+
+	for _, v := range []struct {
+		name string
+		fn   func(b, b1 Bitset, start, end uint) bool
+		test func(b, b1 Bitset, start, end uint) bool
+	}{
+		{"IsSuperSetRange", Bitset.IsSuperSetRange, superset},
+		{"IsStrictSuperSetRange", Bitset.IsStrictSuperSetRange, func(b, b1 Bitset, start, end uint) bool {
+			return superset(b, b1, start, end) && b.CountRange(start, end) > b1.CountRange(start, end)
+		}},
+		{"IsSubSetRange", Bitset.IsSubSetRange, func(b, b1 Bitset, start, end uint) bool {
+			return superset(b1, b, start, end)
+		}},
+		{"IsStrictSubSetRange", Bitset.IsStrictSubSetRange, func(b, b1 Bitset, start, end uint) bool {
+			return superset(b1, b, start, end) && b1.CountRange(start, end) > b.CountRange(start, end)
+		}},
+		{"IntersectsRange", Bitset.IntersectsRange, intersects},
+		{"IsDisjointRange", Bitset.IsDisjointRange, func(b, b1 Bitset, start, end uint) bool {
+			return !intersects(b, b1, start, end)
+		}},
+	} {
+		if err := quick.CheckEqual(v.test, v.fn, &quick.Config{
+			Values:        rangeTestSupersetValues,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func BenchmarkIntersects(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	rand.Read(b1)
+	b2.Complement(b1)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.Intersects(b2)
+	}
+}
+
+func BenchmarkIsStrictSuperSet(b *testing.B) {
+	b1, b2 := New(1<<20), New(1<<20)
+	rand.Read(b1)
+	b2.Intersection(b1, b1)
+	b2.Clear(b2.Len() - 1)
+	b1.Set(b1.Len() - 1)
+
+	b.SetBytes(int64(len(b1)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b1.IsStrictSuperSet(b2)
+	}
+}
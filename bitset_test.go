@@ -172,6 +172,10 @@ func TestString(t *testing.T) {
 	if exp, got := "Bitset{"+x+x+x+x+"...}", b.String(); exp != got {
 		t.Errorf("String failed, expected %s, got %s", exp, got)
 	}
+
+	if exp, got := "Bitset{"+x+x+x+x+x+x+x+x+"}", b.FullString(); exp != got {
+		t.Errorf("FullString failed, expected %s, got %s", exp, got)
+	}
 }
 
 func BenchmarkLen(b *testing.B) {
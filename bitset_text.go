@@ -0,0 +1,280 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tmthrgd/go-hex"
+)
+
+// TextEncoding selects the textual representation
+// used by EncodeText and DecodeText.
+type TextEncoding int
+
+const (
+	// HexEncoding is the lower-case hex encoding of
+	// the bytes of the Bitset, as used by String.
+	HexEncoding TextEncoding = iota
+
+	// Base64Encoding is the standard, padded base64
+	// encoding of the bytes of the Bitset.
+	Base64Encoding
+
+	// ListEncoding is a comma-separated list of the
+	// indices of the set bits, with runs written as
+	// inclusive ranges, e.g. "1,3-7,42".
+	ListEncoding
+)
+
+// MaxListLen is the largest Bitset, in bits, that
+// DecodeText allocates for ListEncoding. Decoding a list
+// with a larger index into an empty Bitset fails, so
+// untrusted input cannot force a huge allocation.
+const MaxListLen = 1 << 28
+
+var errUnknownTextEncoding = errors.New("go-bitset: unknown text encoding")
+
+func (b Bitset) EncodeText(enc TextEncoding) []byte {
+	switch enc {
+	case HexEncoding:
+		dst := make([]byte, hex.EncodedLen(len(b)))
+		hex.Encode(dst, b)
+		return dst
+	case Base64Encoding:
+		dst := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+		base64.StdEncoding.Encode(dst, b)
+		return dst
+	case ListEncoding:
+		return b.appendList(nil)
+	default:
+		panic(errUnknownTextEncoding)
+	}
+}
+
+func (b Bitset) appendList(dst []byte) []byte {
+	for start, ok := b.NextSet(0); ok; start, ok = b.NextSet(start) {
+		end, ok := b.NextClear(start)
+		if !ok {
+			end = b.Len()
+		}
+
+		if len(dst) != 0 {
+			dst = append(dst, ',')
+		}
+
+		dst = strconv.AppendUint(dst, uint64(start), 10)
+
+		if end-start > 1 {
+			dst = append(dst, '-')
+			dst = strconv.AppendUint(dst, uint64(end-1), 10)
+		}
+
+		start = end
+	}
+
+	return dst
+}
+
+// DecodeText decodes text in the given encoding into b.
+//
+// If b is empty, a new Bitset is allocated to hold the
+// result, of at most MaxListLen bits for ListEncoding.
+// Otherwise the decoded Bitset must fit within
+// b and, for HexEncoding and Base64Encoding, have
+// exactly the same length as b.
+func (b *Bitset) DecodeText(text []byte, enc TextEncoding) error {
+	switch enc {
+	case HexEncoding:
+		b1, err := hex.DecodeString(string(text))
+		if err != nil {
+			return fmt.Errorf("go-bitset: invalid hex: %v", err)
+		}
+
+		return b.decodeBytes(b1)
+	case Base64Encoding:
+		b1, err := base64.StdEncoding.DecodeString(string(text))
+		if err != nil {
+			return fmt.Errorf("go-bitset: invalid base64: %v", err)
+		}
+
+		return b.decodeBytes(b1)
+	case ListEncoding:
+		return b.decodeList(string(text))
+	default:
+		return errUnknownTextEncoding
+	}
+}
+
+func (b *Bitset) decodeBytes(b1 []byte) error {
+	if len(*b) == 0 {
+		*b = b1
+		return nil
+	}
+
+	if len(b1) != len(*b) {
+		return fmt.Errorf("go-bitset: length mismatch, expected %d bytes, got %d", len(*b), len(b1))
+	}
+
+	copy(*b, b1)
+	return nil
+}
+
+type bitRange struct {
+	start, end uint
+}
+
+func parseList(s string) (ranges []bitRange, max uint, err error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, 0, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+
+		lo, hi := item, item
+		if i := strings.IndexByte(item, '-'); i >= 0 {
+			lo, hi = item[:i], item[i+1:]
+		}
+
+		start, err := strconv.ParseUint(strings.TrimSpace(lo), 10, 0)
+		if err != nil {
+			return nil, 0, fmt.Errorf("go-bitset: invalid range %q: %v", item, err)
+		}
+
+		end, err := strconv.ParseUint(strings.TrimSpace(hi), 10, 0)
+		if err != nil {
+			return nil, 0, fmt.Errorf("go-bitset: invalid range %q: %v", item, err)
+		}
+
+		if start > end {
+			return nil, 0, fmt.Errorf("go-bitset: invalid range %q: cannot range backwards", item)
+		}
+
+		if end == uint64(^uint(0)) {
+			return nil, 0, fmt.Errorf("go-bitset: invalid range %q: out of range", item)
+		}
+
+		ranges = append(ranges, bitRange{uint(start), uint(end) + 1})
+
+		if uint(end)+1 > max {
+			max = uint(end) + 1
+		}
+	}
+
+	return ranges, max, nil
+}
+
+func (b *Bitset) decodeList(s string) error {
+	ranges, max, err := parseList(s)
+	if err != nil {
+		return err
+	}
+
+	if len(*b) == 0 {
+		if max > MaxListLen {
+			return fmt.Errorf("go-bitset: bit %d exceeds MaxListLen", max-1)
+		}
+
+		*b = New(max)
+	} else if max > b.Len() {
+		return fmt.Errorf("go-bitset: bit %d out of range for Bitset of length %d", max-1, b.Len())
+	} else {
+		b.ClearAll()
+	}
+
+	for _, r := range ranges {
+		b.SetRange(r.start, r.end)
+	}
+
+	return nil
+}
+
+func (b Bitset) MarshalText() ([]byte, error) {
+	return b.EncodeText(HexEncoding), nil
+}
+
+func (b *Bitset) UnmarshalText(text []byte) error {
+	return b.DecodeText(text, HexEncoding)
+}
+
+func (b Bitset) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(b, HexEncoding), nil
+}
+
+func (b *Bitset) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(b, data, HexEncoding)
+}
+
+func marshalJSONText(b Bitset, enc TextEncoding) []byte {
+	// None of the encodings produce characters that
+	// need to be escaped within a JSON string.
+	text := b.EncodeText(enc)
+
+	data := make([]byte, len(text)+2)
+	data[0] = '"'
+	copy(data[1:], text)
+	data[len(data)-1] = '"'
+	return data
+}
+
+func unmarshalJSONText(b *Bitset, data []byte, enc TextEncoding) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return b.DecodeText([]byte(s), enc)
+}
+
+// Base64Bitset is a Bitset that marshals to and from
+// text and JSON with Base64Encoding.
+type Base64Bitset Bitset
+
+func (b Base64Bitset) MarshalText() ([]byte, error) {
+	return Bitset(b).EncodeText(Base64Encoding), nil
+}
+
+func (b *Base64Bitset) UnmarshalText(text []byte) error {
+	return (*Bitset)(b).DecodeText(text, Base64Encoding)
+}
+
+func (b Base64Bitset) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(Bitset(b), Base64Encoding), nil
+}
+
+func (b *Base64Bitset) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText((*Bitset)(b), data, Base64Encoding)
+}
+
+// ListBitset is a Bitset that marshals to and from
+// text and JSON with ListEncoding.
+type ListBitset Bitset
+
+func (b ListBitset) MarshalText() ([]byte, error) {
+	return Bitset(b).EncodeText(ListEncoding), nil
+}
+
+func (b *ListBitset) UnmarshalText(text []byte) error {
+	return (*Bitset)(b).DecodeText(text, ListEncoding)
+}
+
+func (b ListBitset) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(Bitset(b), ListEncoding), nil
+}
+
+func (b *ListBitset) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText((*Bitset)(b), data, ListEncoding)
+}
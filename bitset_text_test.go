@@ -0,0 +1,152 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func TestEncodeText(t *testing.T) {
+	b := New(48)
+	b.Set(1)
+	b.SetRange(3, 8)
+	b.Set(42)
+
+	for _, v := range []struct {
+		enc TextEncoding
+		exp string
+	}{
+		{HexEncoding, "fa0000000004"},
+		{Base64Encoding, "+gAAAAAE"},
+		{ListEncoding, "1,3-7,42"},
+	} {
+		if got := string(b.EncodeText(v.enc)); got != v.exp {
+			t.Errorf("EncodeText(%d) failed, expected %q, got %q", v.enc, v.exp, got)
+		}
+
+		b1 := New(b.Len())
+		if err := b1.DecodeText([]byte(v.exp), v.enc); err != nil {
+			t.Errorf("DecodeText(%d) failed: %v", v.enc, err)
+		} else if !b1.Equal(b) {
+			t.Errorf("DecodeText(%d) failed, expected %s, got %s", v.enc, b, b1)
+		}
+	}
+
+	b.SetRange(40, 48)
+
+	if exp, got := "1,3-7,40-47", string(b.EncodeText(ListEncoding)); got != exp {
+		t.Errorf("EncodeText(ListEncoding) failed, expected %q, got %q", exp, got)
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	for _, enc := range []TextEncoding{HexEncoding, Base64Encoding, ListEncoding} {
+		if err := quick.Check(func(b Bitset) bool {
+			var b1 Bitset
+			if err := b1.DecodeText(b.EncodeText(enc), enc); err != nil {
+				return false
+			}
+
+			if enc == ListEncoding {
+				return b1.Count() == b.Count() && b.IsSuperSet(b1) && b1.IsSuperSet(b)
+			}
+
+			return b1.Equal(b)
+		}, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestDecodeTextInvalid(t *testing.T) {
+	for _, v := range []struct {
+		text string
+		enc  TextEncoding
+		size uint
+		err  string
+	}{
+		{"abc", HexEncoding, 0, "invalid hex"},
+		{"zz", HexEncoding, 0, "invalid hex"},
+		{"0000", HexEncoding, 8, "length mismatch"},
+		{"!!!!", Base64Encoding, 0, "invalid base64"},
+		{"1,x", ListEncoding, 0, `invalid range "x"`},
+		{"7-3", ListEncoding, 0, `invalid range "7-3": cannot range backwards`},
+		{"1-", ListEncoding, 0, `invalid range "1-"`},
+		{"1,,2", ListEncoding, 0, `invalid range ""`},
+		{"-1", ListEncoding, 0, `invalid range "-1"`},
+		{"1,16", ListEncoding, 16, "bit 16 out of range"},
+		{"268435456", ListEncoding, 0, "bit 268435456 exceeds MaxListLen"},
+		{"1-9223372036854775807", ListEncoding, 0, "go-bitset:"},
+		{"18446744073709551614", ListEncoding, 0, "go-bitset:"},
+		{"18446744073709551615", ListEncoding, 0, "out of range"},
+		{"18446744073709551616", ListEncoding, 0, "out of range"},
+		{"", TextEncoding(-1), 0, "unknown text encoding"},
+	} {
+		b := New(v.size)
+		if err := b.DecodeText([]byte(v.text), v.enc); err == nil || !strings.Contains(err.Error(), v.err) {
+			t.Errorf("DecodeText(%q, %d) failed, expected error containing %q, got %v", v.text, v.enc, v.err, err)
+		}
+	}
+}
+
+func TestBitsetJSON(t *testing.T) {
+	type T struct {
+		Hex    Bitset
+		Base64 Base64Bitset
+		List   ListBitset
+	}
+
+	b := New(16)
+	b.SetRange(2, 5)
+	b.Set(15)
+
+	v := T{b, Base64Bitset(b), ListBitset(b)}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := `{"Hex":"1c80","Base64":"HIA=","List":"2-4,15"}`; string(data) != exp {
+		t.Errorf("json.Marshal failed, expected %s, got %s", exp, data)
+	}
+
+	var v1 T
+	if err := json.Unmarshal(data, &v1); err != nil {
+		t.Fatal(err)
+	}
+
+	if !v1.Hex.Equal(b) || !Bitset(v1.Base64).Equal(b) || !Bitset(v1.List).Equal(b) {
+		t.Errorf("json.Unmarshal failed, expected %v, got %v", v, v1)
+	}
+
+	if err := json.Unmarshal([]byte(`{"List":"3-1"}`), &v1); err == nil {
+		t.Error("json.Unmarshal did not fail for invalid range")
+	}
+
+	for _, s := range []string{"18446744073709551614", "9223372036854775807"} {
+		var l ListBitset
+		if err := json.Unmarshal([]byte(`"`+s+`"`), &l); err == nil {
+			t.Errorf("json.Unmarshal did not fail for index %s", s)
+		}
+	}
+}
+
+func BenchmarkEncodeTextList(b *testing.B) {
+	bs := New(1 << 16)
+	for i := uint(0); i < bs.Len(); i += 7 {
+		bs.SetRange(i, i+3)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var _ = bs.EncodeText(ListEncoding)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"io"
+	"math/bits"
+)
+
+var (
+	errBitStreamInvalid = errors.New("go-bitset: invalid bit stream code")
+	errBitStreamSeek    = errors.New("go-bitset: invalid BitReader seek")
+	errZeroCode         = errors.New("go-bitset: Elias codes cannot encode zero")
+)
+
+// BitWriter appends values to a growing Sized bitset.
+//
+// Multi-bit values are written least significant bit
+// first, so that they may be read back with GetBits. The
+// zero value is an empty BitWriter ready to use.
+type BitWriter struct {
+	s Sized
+}
+
+// Sized returns the bits written so far. It shares
+// storage with w until the next write.
+func (w *BitWriter) Sized() *Sized {
+	return &w.s
+}
+
+func (w *BitWriter) Len() uint {
+	return w.s.n
+}
+
+// Reset discards everything written but keeps the
+// allocated storage.
+func (w *BitWriter) Reset() {
+	w.s.Resize(0)
+}
+
+// Align pads w with clear bits to a byte boundary.
+func (w *BitWriter) Align() {
+	w.s.Resize((w.s.n + 7) &^ 7)
+}
+
+func (w *BitWriter) WriteBit(bit bool) {
+	w.s.AppendBit(bit)
+}
+
+// WriteBits writes the low width bits of value. width must
+// be at most 64.
+func (w *BitWriter) WriteBits(value uint64, width uint) {
+	w.s.AppendBits(value, width)
+}
+
+// WriteUnary writes n as n clear bits followed by a set
+// bit.
+func (w *BitWriter) WriteUnary(n uint) {
+	if n > ^uint(0)-1-w.s.n {
+		panic(errOutOfRange)
+	}
+
+	w.s.Resize(w.s.n + n + 1)
+	w.s.b.Set(w.s.n - 1)
+}
+
+// WriteGamma writes n, which must be at least 1, with the
+// Elias gamma code: the number of bits, N, that follow the
+// leading one of n in unary, then those N bits.
+func (w *BitWriter) WriteGamma(n uint64) {
+	if n == 0 {
+		panic(errZeroCode)
+	}
+
+	l := uint(bits.Len64(n)) - 1
+	w.WriteUnary(l)
+	w.WriteBits(n, l)
+}
+
+// WriteDelta writes n, which must be at least 1, with the
+// Elias delta code: N+1 in the Elias gamma code, where N
+// is the number of bits that follow the leading one of n,
+// then those N bits.
+func (w *BitWriter) WriteDelta(n uint64) {
+	if n == 0 {
+		panic(errZeroCode)
+	}
+
+	l := uint(bits.Len64(n)) - 1
+	w.WriteGamma(uint64(l) + 1)
+	w.WriteBits(n, l)
+}
+
+// Write implements io.Writer, writing each byte as eight
+// bits. It never returns an error.
+func (w *BitWriter) Write(p []byte) (int, error) {
+	if w.s.n&7 == 0 {
+		off := len(w.s.b)
+		w.s.Resize(w.s.n + uint(len(p))<<3)
+		copy(w.s.b[off:], p)
+		return len(p), nil
+	}
+
+	w.s.Grow(uint(len(p)) << 3)
+
+	for _, c := range p {
+		w.s.AppendBits(uint64(c), 8)
+	}
+
+	return len(p), nil
+}
+
+// WriteByte implements io.ByteWriter. It never returns an
+// error.
+func (w *BitWriter) WriteByte(c byte) error {
+	w.s.AppendBits(uint64(c), 8)
+	return nil
+}
+
+// BitReader reads values, written by a BitWriter, from a
+// Bitset.
+type BitReader struct {
+	b   Bitset
+	n   uint
+	pos uint
+}
+
+// NewBitReader returns a BitReader that reads all of b.
+func NewBitReader(b Bitset) *BitReader {
+	return &BitReader{b: b, n: b.Len()}
+}
+
+// NewSizedBitReader returns a BitReader that reads the
+// first s.Len() bits of s.
+func NewSizedBitReader(s *Sized) *BitReader {
+	return &BitReader{b: s.b, n: s.n}
+}
+
+func (r *BitReader) Len() uint {
+	return r.n
+}
+
+// Pos returns the index of the next bit to be read.
+func (r *BitReader) Pos() uint {
+	return r.pos
+}
+
+// Remaining returns the number of bits left to read.
+func (r *BitReader) Remaining() uint {
+	return r.n - r.pos
+}
+
+// Seek implements io.Seeker, but offset and the returned
+// position are measured in bits rather than bytes.
+func (r *BitReader) Seek(offset int64, whence int) (int64, error) {
+	var base int64
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		base = int64(r.pos)
+	case io.SeekEnd:
+		base = int64(r.n)
+	default:
+		return 0, errBitStreamSeek
+	}
+
+	pos := base + offset
+	if pos < 0 || pos > int64(r.n) {
+		return 0, errBitStreamSeek
+	}
+
+	r.pos = uint(pos)
+	return pos, nil
+}
+
+// Align skips to the next byte boundary.
+func (r *BitReader) Align() {
+	r.pos = (r.pos + 7) &^ 7
+	if r.pos > r.n {
+		r.pos = r.n
+	}
+}
+
+// eof returns the error for a read that would go past
+// the end of r.
+func (r *BitReader) eof() error {
+	if r.pos == r.n {
+		return io.EOF
+	}
+
+	return io.ErrUnexpectedEOF
+}
+
+func (r *BitReader) ReadBit() (bool, error) {
+	if r.pos >= r.n {
+		return false, io.EOF
+	}
+
+	r.pos++
+	return r.b.IsSet(r.pos - 1), nil
+}
+
+// ReadBits reads a width bit value. width must be at most
+// 64. If fewer than width bits remain, ReadBits returns
+// io.ErrUnexpectedEOF and does not advance.
+func (r *BitReader) ReadBits(width uint) (uint64, error) {
+	if width > 64 {
+		panic(errInvalidWidth)
+	}
+
+	if width > r.n-r.pos {
+		return 0, r.eof()
+	}
+
+	r.pos += width
+	return r.b.GetBits(r.pos-width, width), nil
+}
+
+func (r *BitReader) ReadUnary() (uint, error) {
+	bit, ok := r.b.NextSet(r.pos)
+	if !ok || bit >= r.n {
+		return 0, r.eof()
+	}
+
+	n := bit - r.pos
+	r.pos = bit + 1
+	return n, nil
+}
+
+// readElias reads the N bits that follow the leading one
+// of an Elias coded value.
+func (r *BitReader) readElias(start, l uint) (uint64, error) {
+	if l > 63 {
+		r.pos = start
+		return 0, errBitStreamInvalid
+	}
+
+	x, err := r.ReadBits(l)
+	if err != nil {
+		r.pos = start
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	return 1<<l | x, nil
+}
+
+func (r *BitReader) ReadGamma() (uint64, error) {
+	start := r.pos
+
+	l, err := r.ReadUnary()
+	if err != nil {
+		return 0, err
+	}
+
+	return r.readElias(start, l)
+}
+
+func (r *BitReader) ReadDelta() (uint64, error) {
+	start := r.pos
+
+	l, err := r.ReadGamma()
+	if err != nil {
+		return 0, err
+	}
+
+	if l-1 > 63 {
+		r.pos = start
+		return 0, errBitStreamInvalid
+	}
+
+	return r.readElias(start, uint(l-1))
+}
+
+// Read implements io.Reader, reading eight bits for each
+// byte. It only reads whole bytes; if fewer than eight
+// bits remain, it returns io.ErrUnexpectedEOF.
+func (r *BitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	n := int((r.n - r.pos) >> 3)
+	if n == 0 {
+		return 0, r.eof()
+	}
+
+	if n > len(p) {
+		n = len(p)
+	}
+
+	if r.pos&7 == 0 {
+		copy(p[:n], r.b[r.pos>>3:])
+		r.pos += uint(n) << 3
+		return n, nil
+	}
+
+	for i := range p[:n] {
+		p[i] = byte(r.b.GetBits(r.pos, 8))
+		r.pos += 8
+	}
+
+	return n, nil
+}
+
+// ReadByte implements io.ByteReader.
+func (r *BitReader) ReadByte() (byte, error) {
+	x, err := r.ReadBits(8)
+	return byte(x), err
+}
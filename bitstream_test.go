@@ -0,0 +1,297 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestBitStream(t *testing.T) {
+	if err := quick.Check(func(seed int64) bool {
+		rand := rand.New(rand.NewSource(seed))
+
+		type value struct {
+			kind  int
+			x     uint64
+			width uint
+		}
+
+		values := make([]value, rand.Intn(200))
+
+		var w BitWriter
+		for i := range values {
+			v := &values[i]
+			v.kind = rand.Intn(5)
+
+			switch v.kind {
+			case 0:
+				v.width = uint(rand.Intn(65))
+				v.x = rand.Uint64() & widthMask(v.width)
+				w.WriteBits(v.x, v.width)
+			case 1:
+				v.x = uint64(rand.Intn(100))
+				w.WriteUnary(uint(v.x))
+			case 2:
+				v.x = rand.Uint64()>>uint(rand.Intn(64)) | 1
+				w.WriteGamma(v.x)
+			case 3:
+				v.x = rand.Uint64()>>uint(rand.Intn(64)) | 1
+				w.WriteDelta(v.x)
+			case 4:
+				v.x = uint64(rand.Intn(2))
+				w.WriteBit(v.x == 1)
+			}
+		}
+
+		r := NewSizedBitReader(w.Sized())
+
+		for _, v := range values {
+			var (
+				x   uint64
+				err error
+			)
+
+			switch v.kind {
+			case 0:
+				x, err = r.ReadBits(v.width)
+			case 1:
+				var n uint
+				n, err = r.ReadUnary()
+				x = uint64(n)
+			case 2:
+				x, err = r.ReadGamma()
+			case 3:
+				x, err = r.ReadDelta()
+			case 4:
+				var bit bool
+				bit, err = r.ReadBit()
+				if bit {
+					x = 1
+				}
+			}
+
+			if err != nil || x != v.x {
+				return false
+			}
+		}
+
+		_, err := r.ReadBit()
+		return r.Remaining() == 0 && r.Pos() == w.Len() && err == io.EOF
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBitStreamCodes(t *testing.T) {
+	for _, v := range []struct {
+		fn    func(w *BitWriter, n uint64)
+		n     uint64
+		width uint
+	}{
+		{(*BitWriter).WriteGamma, 1, 1},
+		{(*BitWriter).WriteGamma, 2, 3},
+		{(*BitWriter).WriteGamma, 5, 5},
+		{(*BitWriter).WriteGamma, 1<<64 - 1, 127},
+		{(*BitWriter).WriteDelta, 1, 1},
+		{(*BitWriter).WriteDelta, 2, 4},
+		{(*BitWriter).WriteDelta, 17, 9},
+		{(*BitWriter).WriteDelta, 1<<64 - 1, 76},
+	} {
+		var w BitWriter
+		v.fn(&w, v.n)
+
+		if w.Len() != v.width {
+			t.Errorf("code for %d has length %d, expected %d", v.n, w.Len(), v.width)
+		}
+	}
+
+	var w BitWriter
+	w.WriteGamma(5)
+
+	// 2 in unary is 001, then the two bits of 5 (101)
+	// below its leading one, least significant first.
+	if x := w.Sized().GetBits(0, 5); x != 0x0c {
+		t.Errorf("WriteGamma(5) wrote %05b", x)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WriteGamma did not panic for zero")
+		}
+	}()
+
+	w.WriteGamma(0)
+}
+
+func TestBitReaderErrors(t *testing.T) {
+	var w BitWriter
+	w.WriteUnary(70)
+
+	r := NewSizedBitReader(w.Sized())
+
+	if _, err := r.ReadGamma(); err != errBitStreamInvalid {
+		t.Errorf("ReadGamma returned %v, expected %v", err, errBitStreamInvalid)
+	}
+
+	if r.Pos() != 0 {
+		t.Errorf("ReadGamma advanced to %d on error", r.Pos())
+	}
+
+	w.Reset()
+	w.WriteGamma(1 << 20)
+	w.Sized().Truncate(w.Len() - 1)
+
+	r = NewSizedBitReader(w.Sized())
+
+	if _, err := r.ReadGamma(); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadGamma returned %v, expected %v", err, io.ErrUnexpectedEOF)
+	}
+
+	if _, err := r.ReadBits(w.Len() + 1); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits returned %v, expected %v", err, io.ErrUnexpectedEOF)
+	}
+
+	r.Seek(0, io.SeekEnd)
+
+	if _, err := r.ReadUnary(); err != io.EOF {
+		t.Errorf("ReadUnary returned %v, expected %v", err, io.EOF)
+	}
+
+	for _, l := range []uint64{65, 1<<32 + 1, 1<<63 + 1} {
+		w.Reset()
+		w.WriteGamma(l)
+		w.WriteBits(^uint64(0), 64)
+
+		r = NewSizedBitReader(w.Sized())
+
+		if _, err := r.ReadDelta(); err != errBitStreamInvalid {
+			t.Errorf("ReadDelta returned %v for length %d, expected %v", err, l-1, errBitStreamInvalid)
+		}
+
+		if r.Pos() != 0 {
+			t.Errorf("ReadDelta advanced to %d on error", r.Pos())
+		}
+	}
+}
+
+func TestBitWriterUnaryOverflow(t *testing.T) {
+	var w BitWriter
+	w.WriteBits(0, 8)
+
+	defer func() {
+		if err := recover(); err != errOutOfRange {
+			t.Errorf("WriteUnary did not panic with %v, got %v", errOutOfRange, err)
+		}
+
+		if w.Len() != 8 || w.Sized().Count() != 0 {
+			t.Errorf("WriteUnary modified the stream, got %s", w.Sized())
+		}
+	}()
+
+	w.WriteUnary(^uint(0))
+}
+
+func TestBitReaderSeek(t *testing.T) {
+	r := NewBitReader(New(80))
+
+	for _, v := range []struct {
+		offset int64
+		whence int
+		pos    int64
+		err    bool
+	}{
+		{10, io.SeekStart, 10, false},
+		{5, io.SeekCurrent, 15, false},
+		{-5, io.SeekEnd, 75, false},
+		{1, io.SeekEnd, 0, true},
+		{-76, io.SeekCurrent, 0, true},
+		{0, 42, 0, true},
+	} {
+		pos, err := r.Seek(v.offset, v.whence)
+		if (err != nil) != v.err || !v.err && pos != v.pos {
+			t.Errorf("Seek(%d, %d) returned (%d, %v)", v.offset, v.whence, pos, err)
+		}
+	}
+
+	if r.Pos() != 75 || r.Remaining() != 5 {
+		t.Errorf("Seek failed, at %d with %d remaining", r.Pos(), r.Remaining())
+	}
+
+	r.Seek(73, io.SeekStart)
+	r.Align()
+
+	if r.Pos() != 80 {
+		t.Errorf("Align failed, at %d", r.Pos())
+	}
+}
+
+func TestBitStreamBytes(t *testing.T) {
+	if err := quick.Check(func(p []byte, offset uint8) bool {
+		offset &= 7
+
+		var w BitWriter
+		w.WriteBits(0x55, uint(offset))
+		w.Write(p)
+		w.WriteByte(0xa5)
+		w.WriteBits(1, 3)
+
+		r := NewSizedBitReader(w.Sized())
+		r.Seek(int64(offset), io.SeekStart)
+
+		p1 := make([]byte, len(p))
+		if _, err := io.ReadFull(r, p1); err != nil || !bytes.Equal(p, p1) {
+			return false
+		}
+
+		c, err := r.ReadByte()
+		if err != nil || c != 0xa5 {
+			return false
+		}
+
+		n, err := r.Read(make([]byte, 1))
+		return n == 0 && err == io.ErrUnexpectedEOF
+	}, nil); err != nil {
+		t.Error(err)
+	}
+
+	var w BitWriter
+	w.Write([]byte("hello"))
+	w.Align()
+
+	r := NewSizedBitReader(w.Sized())
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil || string(b) != "hello" {
+		t.Errorf("ReadAll returned (%q, %v)", b, err)
+	}
+}
+
+func BenchmarkBitWriterGamma(b *testing.B) {
+	var w BitWriter
+
+	for i := 0; i < b.N; i++ {
+		w.WriteGamma(uint64(i) + 1)
+	}
+}
+
+func BenchmarkBitReaderGamma(b *testing.B) {
+	var w BitWriter
+	for i := 0; i < b.N; i++ {
+		w.WriteGamma(uint64(i) + 1)
+	}
+
+	r := NewSizedBitReader(w.Sized())
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r.ReadGamma()
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Each marker word of an EWAH stream holds the running
+// bit in bit 0, the number of clean words of that bit
+// in the following 32 bits and the number of literal
+// words that follow the marker in the top 31 bits.
+const (
+	ewahRunShift = 1
+	ewahLitShift = 33
+
+	ewahMaxRun = 1<<32 - 1
+	ewahMaxLit = 1<<31 - 1
+)
+
+var errEWAHDecreasing = errors.New("go-bitset: EWAH bits must be set in increasing order")
+
+// EWAH is an Enhanced Word-Aligned Hybrid run-length
+// encoded bitset of 64-bit words.
+//
+// It is append-mostly, bits may only be set at or after
+// the start of the last word. The zero value is an
+// empty bitset.
+type EWAH struct {
+	words []uint64
+
+	// last is the index of the last marker word.
+	last int
+
+	// bits is the logical length, nwords is the number
+	// of uncompressed words that words represents.
+	bits, nwords uint
+}
+
+func NewEWAH() *EWAH {
+	return new(EWAH)
+}
+
+func NewEWAHFromBitset(b Bitset) *EWAH {
+	e := new(EWAH)
+
+	for i := 0; i < len(b); i += 8 {
+		var w [8]byte
+		copy(w[:], b[i:])
+		e.addLiteral(binary.LittleEndian.Uint64(w[:]))
+	}
+
+	e.bits = b.Len()
+	return e
+}
+
+func ewahMarker(m uint64) (runBit bool, run, lits uint64) {
+	return m&1 != 0, m >> ewahRunShift & ewahMaxRun, m >> ewahLitShift
+}
+
+func (e *EWAH) newMarker() {
+	e.last = len(e.words)
+	e.words = append(e.words, 0)
+}
+
+func (e *EWAH) addClean(runBit bool, n uint64) {
+	if n == 0 {
+		return
+	}
+
+	if len(e.words) == 0 {
+		e.newMarker()
+	}
+
+	e.nwords += uint(n)
+
+	for n > 0 {
+		rb, run, lits := ewahMarker(e.words[e.last])
+		if lits != 0 || run != 0 && rb != runBit || run == ewahMaxRun {
+			e.newMarker()
+			run = 0
+		}
+
+		add := ewahMaxRun - run
+		if add > n {
+			add = n
+		}
+
+		m := (run + add) << ewahRunShift
+		if runBit {
+			m |= 1
+		}
+
+		e.words[e.last] = m
+		n -= add
+	}
+}
+
+func (e *EWAH) addLiteral(w uint64) {
+	switch w {
+	case 0:
+		e.addClean(false, 1)
+		return
+	case ^uint64(0):
+		e.addClean(true, 1)
+		return
+	}
+
+	if len(e.words) == 0 {
+		e.newMarker()
+	}
+
+	if _, _, lits := ewahMarker(e.words[e.last]); lits == ewahMaxLit {
+		e.newMarker()
+	}
+
+	e.words[e.last] += 1 << ewahLitShift
+	e.words = append(e.words, w)
+	e.nwords++
+}
+
+func (e *EWAH) Len() uint {
+	return e.bits
+}
+
+// ByteLen returns the size of the compressed stream.
+func (e *EWAH) ByteLen() int {
+	return len(e.words) * 8
+}
+
+// Set sets bit, which must not be before the start of
+// the last word that has already been written to.
+func (e *EWAH) Set(bit uint) {
+	wi := bit / 64
+	if e.nwords > 0 && wi < e.nwords-1 {
+		panic(errEWAHDecreasing)
+	}
+
+	mask := uint64(1) << (bit & 63)
+
+	if wi >= e.nwords {
+		e.addClean(false, uint64(wi-e.nwords))
+		e.addLiteral(mask)
+	} else if rb, run, lits := ewahMarker(e.words[e.last]); lits != 0 {
+		w := e.words[len(e.words)-1] | mask
+		if w != ^uint64(0) {
+			e.words[len(e.words)-1] = w
+		} else {
+			e.words = e.words[:len(e.words)-1]
+			e.words[e.last] -= 1 << ewahLitShift
+			e.nwords--
+			e.addClean(true, 1)
+		}
+	} else if !rb {
+		e.words[e.last] = (run - 1) << ewahRunShift
+		e.nwords--
+		e.addLiteral(mask)
+	}
+
+	if bit >= e.bits {
+		e.bits = bit + 1
+	}
+}
+
+func (e *EWAH) IsSet(bit uint) bool {
+	if bit >= e.bits {
+		return false
+	}
+
+	wi := uint64(bit / 64)
+	for i := 0; i < len(e.words); {
+		rb, run, lits := ewahMarker(e.words[i])
+		if wi < run {
+			return rb
+		}
+
+		wi -= run
+
+		if wi < lits {
+			return e.words[i+1+int(wi)]&(1<<(bit&63)) != 0
+		}
+
+		wi -= lits
+		i += 1 + int(lits)
+	}
+
+	return false
+}
+
+func (e *EWAH) Count() uint {
+	var n uint64
+	for i := 0; i < len(e.words); {
+		rb, run, lits := ewahMarker(e.words[i])
+		if rb {
+			n += run * 64
+		}
+
+		for _, w := range e.words[i+1 : i+1+int(lits)] {
+			n += uint64(bits.OnesCount64(w))
+		}
+
+		i += 1 + int(lits)
+	}
+
+	if n > uint64(e.bits) {
+		// A final clean word of ones may extend past
+		// the logical length.
+		n = uint64(e.bits)
+	}
+
+	return uint(n)
+}
+
+// Bitset returns the decompressed bitset.
+func (e *EWAH) Bitset() Bitset {
+	b := New(e.bits)
+
+	var off int
+	for i := 0; i < len(e.words) && off < len(b); {
+		rb, run, lits := ewahMarker(e.words[i])
+
+		n := int(run) * 8
+		if n > len(b)-off {
+			n = len(b) - off
+		}
+
+		if rb {
+			b[off : off+n].SetAll()
+		}
+
+		off += n
+
+		for _, w := range e.words[i+1 : i+1+int(lits)] {
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], w)
+			off += copy(b[off:], buf[:])
+		}
+
+		i += 1 + int(lits)
+	}
+
+	return b
+}
+
+// each calls fn for each set bit in order until fn
+// returns false.
+func (e *EWAH) each(fn func(uint) bool) {
+	var off uint
+	for i := 0; i < len(e.words); {
+		rb, run, lits := ewahMarker(e.words[i])
+
+		if rb {
+			for bit := off; bit < off+uint(run)*64 && bit < e.bits; bit++ {
+				if !fn(bit) {
+					return
+				}
+			}
+		}
+
+		off += uint(run) * 64
+
+		for _, w := range e.words[i+1 : i+1+int(lits)] {
+			for ; w != 0; w &= w - 1 {
+				if !fn(off + uint(bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+
+			off += 64
+		}
+
+		i += 1 + int(lits)
+	}
+}
+
+func (e *EWAH) Union(e1, e2 *EWAH) {
+	e.combine(e1, e2, func(a, b uint64) uint64 { return a | b })
+}
+
+func (e *EWAH) Intersection(e1, e2 *EWAH) {
+	e.combine(e1, e2, func(a, b uint64) uint64 { return a & b })
+}
+
+func (e *EWAH) Difference(e1, e2 *EWAH) {
+	e.combine(e1, e2, func(a, b uint64) uint64 { return a &^ b })
+}
+
+func (e *EWAH) SymmetricDifference(e1, e2 *EWAH) {
+	e.combine(e1, e2, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// ewahReader walks an EWAH stream as a sequence of
+// clean runs and literal words. Past the end of the
+// stream it reads as an endless run of zeros.
+type ewahReader struct {
+	words []uint64
+	next  int
+
+	runBit    bool
+	run, lits uint64
+}
+
+func (r *ewahReader) fill() {
+	for r.run == 0 && r.lits == 0 {
+		if r.next == len(r.words) {
+			r.runBit, r.run = false, ^uint64(0)
+			return
+		}
+
+		r.runBit, r.run, r.lits = ewahMarker(r.words[r.next])
+		r.next++
+	}
+}
+
+func (r *ewahReader) literal() uint64 {
+	w := r.words[r.next]
+	r.next++
+	r.lits--
+	return w
+}
+
+func (e *EWAH) combine(e1, e2 *EWAH, op func(a, b uint64) uint64) {
+	var out EWAH
+
+	nwords := e1.nwords
+	if e2.nwords > nwords {
+		nwords = e2.nwords
+	}
+
+	r1, r2 := &ewahReader{words: e1.words}, &ewahReader{words: e2.words}
+
+	for out.nwords < nwords {
+		r1.fill()
+		r2.fill()
+
+		remaining := uint64(nwords - out.nwords)
+
+		switch {
+		case r1.run != 0 && r2.run != 0:
+			n := min64(r1.run, r2.run, remaining)
+			out.addClean(op(ewahWord(r1.runBit), ewahWord(r2.runBit)) != 0, n)
+			r1.run -= n
+			r2.run -= n
+		case r1.run != 0 || r2.run != 0:
+			run, lit, swap := r1, r2, false
+			if r2.run != 0 {
+				run, lit, swap = r2, r1, true
+			}
+
+			n := min64(run.run, lit.lits, remaining)
+			run.run -= n
+
+			// If the result does not depend on the literal
+			// words, skip over them as a single clean run.
+			rw := ewahWord(run.runBit)
+			if lo, hi := ewahOp(op, rw, 0, swap), ewahOp(op, rw, ^uint64(0), swap); lo == hi {
+				out.addClean(lo != 0, n)
+				lit.next += int(n)
+				lit.lits -= n
+				break
+			}
+
+			for ; n > 0; n-- {
+				out.addLiteral(ewahOp(op, rw, lit.literal(), swap))
+			}
+		default:
+			for n := min64(r1.lits, r2.lits, remaining); n > 0; n-- {
+				out.addLiteral(op(r1.literal(), r2.literal()))
+			}
+		}
+	}
+
+	out.bits = e1.bits
+	if e2.bits > out.bits {
+		out.bits = e2.bits
+	}
+
+	*e = out
+}
+
+func ewahWord(runBit bool) uint64 {
+	if runBit {
+		return ^uint64(0)
+	}
+
+	return 0
+}
+
+func ewahOp(op func(a, b uint64) uint64, a, b uint64, swap bool) uint64 {
+	if swap {
+		return op(b, a)
+	}
+
+	return op(a, b)
+}
+
+func min64(a, b, c uint64) uint64 {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
+
+func (e *EWAH) String() string {
+	return fmt.Sprintf("EWAH{%d,%d}", e.bits, len(e.words))
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import "iter"
+
+// SetBits returns an iterator over the set bits of e
+// that works directly on the compressed stream.
+func (e *EWAH) SetBits() iter.Seq[uint] {
+	return e.each
+}
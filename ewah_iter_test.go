@@ -0,0 +1,34 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package bitset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEWAHSetBits(t *testing.T) {
+	b := New(1 << 12)
+	b.Set(3)
+	b.SetRange(128, 256)
+	b.Set(4000)
+
+	exp := append(append([]uint{3}, testRange(128, 256)...), 4000)
+	if got := slices.Collect(NewEWAHFromBitset(b).SetBits()); !slices.Equal(exp, got) {
+		t.Errorf("SetBits failed, expected %v, got %v", exp, got)
+	}
+
+	for bit := range NewEWAHFromBitset(b).SetBits() {
+		if bit != 3 {
+			t.Errorf("SetBits failed, expected early termination after 3, got %d", bit)
+		}
+
+		break
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func ewahTestValues(args []reflect.Value, rand *rand.Rand) {
+	for i := range args {
+		b := New(uint(rand.Intn(1 << 14)))
+
+		for j := uint(0); j < b.Len(); {
+			end := j + uint(rand.Intn(1<<10))
+			if end > b.Len() {
+				end = b.Len()
+			}
+
+			switch rand.Intn(3) {
+			case 1:
+				b.SetRange(j, end)
+			case 2:
+				rand.Read(b[j>>3 : end>>3])
+			}
+
+			j = end
+		}
+
+		args[i] = reflect.ValueOf(b)
+	}
+}
+
+func TestEWAHBitset(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		e := NewEWAHFromBitset(b)
+
+		for i := 0; i < 100 && b.Len() != 0; i++ {
+			bit := uint(rand.Intn(int(b.Len())))
+			if e.IsSet(bit) != b.IsSet(bit) {
+				return false
+			}
+		}
+
+		return e.Bitset().Equal(b) && e.Len() == b.Len() && e.Count() == b.Count()
+	}, &quick.Config{
+		Values:        ewahTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEWAHSet(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		e := NewEWAH()
+		for bit, ok := b.NextSet(0); ok; bit, ok = b.NextSet(bit + 1) {
+			e.Set(bit)
+			e.Set(bit)
+		}
+
+		b1 := e.Bitset()
+		return b1.Equal(b[:len(b1)]) && b[len(b1):].None() && e.Count() == b.Count()
+	}, &quick.Config{
+		Values:        ewahTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	e := NewEWAH()
+	e.Set(1000)
+	e.Set(960)
+
+	if e.Count() != 2 || !e.IsSet(960) || !e.IsSet(1000) || e.Len() != 1001 {
+		t.Error("Set failed within last word")
+	}
+
+	for i := uint(1024); i < 1088; i++ {
+		e.Set(i)
+	}
+
+	if e.Count() != 66 || len(e.words) != 3 {
+		t.Errorf("Set failed, expected full word to be a clean run, got %x", e.words)
+	}
+
+	e1 := NewEWAHFromBitset(New(128))
+	e1.Set(100)
+
+	if e1.Count() != 1 || !e1.IsSet(100) || e1.Len() != 128 {
+		t.Error("Set failed within clean run")
+	}
+
+	defer func() {
+		if recover() != errEWAHDecreasing {
+			t.Error("Set did not panic for decreasing bit")
+		}
+	}()
+
+	e.Set(900)
+}
+
+func TestEWAHCompression(t *testing.T) {
+	b := New(1 << 20)
+	b.Set(5)
+	b.SetRange(1<<19, 1<<19+1<<10)
+	b.Set(1<<20 - 1)
+
+	e := NewEWAHFromBitset(b)
+	if e.ByteLen() > 64 {
+		t.Errorf("NewEWAHFromBitset failed to compress, got %d bytes", e.ByteLen())
+	}
+
+	if !e.Bitset().Equal(b) {
+		t.Error("NewEWAHFromBitset failed")
+	}
+}
+
+func TestEWAHBitwise(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(e, e1, e2 *EWAH)
+		fn1  func(b, b1, b2 Bitset)
+	}{
+		{"Union", (*EWAH).Union, Bitset.Union},
+		{"Intersection", (*EWAH).Intersection, Bitset.Intersection},
+		{"Difference", (*EWAH).Difference, Bitset.Difference},
+		{"SymmetricDifference", (*EWAH).SymmetricDifference, Bitset.SymmetricDifference},
+	} {
+		if err := quick.Check(func(b1, b2 Bitset) bool {
+			e1, e2 := NewEWAHFromBitset(b1), NewEWAHFromBitset(b2)
+
+			e := NewEWAH()
+			v.fn(e, e1, e2)
+
+			l := b1.Len()
+			if b2.Len() > l {
+				l = b2.Len()
+			}
+
+			b := New(l)
+			b1, b2 = append(b1.Clone(), make(Bitset, len(b)-len(b1))...), append(b2.Clone(), make(Bitset, len(b)-len(b2))...)
+			v.fn1(b, b1, b2)
+
+			return e.Bitset().Equal(b) && e.Count() == b.Count()
+		}, &quick.Config{
+			Values:        ewahTestValues,
+			MaxCountScale: 10,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestEWAHEach(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		var bits []uint
+		NewEWAHFromBitset(b).each(func(bit uint) bool {
+			bits = append(bits, bit)
+			return true
+		})
+
+		if uint(len(bits)) != b.Count() {
+			return false
+		}
+
+		for _, bit := range bits {
+			if !b.IsSet(bit) {
+				return false
+			}
+		}
+
+		return true
+	}, &quick.Config{
+		Values:        ewahTestValues,
+		MaxCountScale: 10,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkEWAHIntersection(b *testing.B) {
+	bs1, bs2 := New(1<<24), New(1<<24)
+	for i := uint(0); i < bs1.Len(); i += 1 << 16 {
+		bs1.SetRange(i, i+1000)
+		bs2.SetRange(i+500, i+5000)
+	}
+
+	e, e1, e2 := NewEWAH(), NewEWAHFromBitset(bs1), NewEWAHFromBitset(bs2)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		e.Intersection(e1, e2)
+	}
+}
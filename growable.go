@@ -0,0 +1,243 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+// Growable is a Sized bitset that grows as bits are set
+// past its length.
+//
+// Bits past Len read as clear, so IsSet and IsClear
+// never panic and clearing them is a no-op. The other
+// queries inherited from Sized remain bounded by Len.
+type Growable struct {
+	Sized
+
+	max uint
+}
+
+func NewGrowable(size uint) *Growable {
+	return &Growable{Sized: *NewSized(size)}
+}
+
+// SetMaxLen limits the length the Growable may grow to.
+// Growing past it panics. Zero means no limit.
+func (g *Growable) SetMaxLen(max uint) {
+	if max != 0 && g.n > max {
+		panic(errOutOfRange)
+	}
+
+	g.max = max
+}
+
+func (g *Growable) MaxLen() uint {
+	return g.max
+}
+
+func (g *Growable) Clone() *Growable {
+	return &Growable{*g.Sized.Clone(), g.max}
+}
+
+func (g *Growable) checkMax(size uint) {
+	if g.max != 0 && size > g.max {
+		panic(errOutOfRange)
+	}
+}
+
+func (g *Growable) Resize(size uint) {
+	g.checkMax(size)
+	g.Sized.Resize(size)
+}
+
+// extend grows g to at least size bits.
+func (g *Growable) extend(size uint) {
+	if size > g.n {
+		g.Resize(size)
+	}
+}
+
+// extendBit grows g to hold bit.
+func (g *Growable) extendBit(bit uint) {
+	if bit == ^uint(0) {
+		panic(errOutOfRange)
+	}
+
+	g.extend(bit + 1)
+}
+
+func (g *Growable) AppendBit(value bool) {
+	g.checkMax(g.n + 1)
+	g.Sized.AppendBit(value)
+}
+
+func (g *Growable) AppendBits(value uint64, width uint) {
+	g.checkMax(g.n + width)
+	g.Sized.AppendBits(value, width)
+}
+
+func (g *Growable) IsSet(bit uint) bool {
+	return bit < g.n && g.b.IsSet(bit)
+}
+
+func (g *Growable) IsClear(bit uint) bool {
+	return !g.IsSet(bit)
+}
+
+// GetBits is like Sized.GetBits, but bits past Len are
+// read as clear.
+func (g *Growable) GetBits(offset, width uint) uint64 {
+	checkBits(^uint(0), offset, width)
+
+	switch {
+	case offset >= g.n:
+		return 0
+	case offset+width > g.n:
+		return g.b.GetBits(offset, g.n-offset)
+	default:
+		return g.b.GetBits(offset, width)
+	}
+}
+
+func (g *Growable) GetSignedBits(offset, width uint) int64 {
+	return signExtend(g.GetBits(offset, width), width)
+}
+
+// PutBits is like Sized.PutBits, but extends g if the
+// bits are past Len.
+func (g *Growable) PutBits(offset, width uint, value uint64) {
+	checkBits(^uint(0), offset, width)
+	g.extend(offset + width)
+	g.b.PutBits(offset, width, value)
+}
+
+func (g *Growable) PutSignedBits(offset, width uint, value int64) {
+	g.PutBits(offset, width, uint64(value))
+}
+
+func (g *Growable) Set(bit uint) {
+	g.extendBit(bit)
+	g.b.Set(bit)
+}
+
+func (g *Growable) Clear(bit uint) {
+	if bit < g.n {
+		g.b.Clear(bit)
+	}
+}
+
+func (g *Growable) Invert(bit uint) {
+	g.extendBit(bit)
+	g.b.Invert(bit)
+}
+
+func (g *Growable) SetTo(bit uint, value bool) {
+	if value {
+		g.Set(bit)
+	} else {
+		g.Clear(bit)
+	}
+}
+
+func (g *Growable) SetRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	g.extend(end)
+	g.b.SetRange(start, end)
+}
+
+func (g *Growable) ClearRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > g.n {
+		end = g.n
+	}
+
+	if start < end {
+		g.b.ClearRange(start, end)
+	}
+}
+
+func (g *Growable) InvertRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	g.extend(end)
+	g.b.InvertRange(start, end)
+}
+
+func (g *Growable) SetRangeTo(start, end uint, value bool) {
+	if value {
+		g.SetRange(start, end)
+	} else {
+		g.ClearRange(start, end)
+	}
+}
+
+func (g *Growable) Equal(g1 *Growable) bool {
+	return g.Sized.Equal(&g1.Sized)
+}
+
+// Union sets g to g1 | g2. The shorter operand is
+// treated as if it were extended with clear bits, the
+// result has the length of the longer operand.
+func (g *Growable) Union(g1, g2 *Growable) {
+	g.combine(g1, g2, Bitset.Union, true, true)
+}
+
+// Intersection sets g to g1 & g2, with the same length
+// semantics as Union.
+func (g *Growable) Intersection(g1, g2 *Growable) {
+	g.combine(g1, g2, Bitset.Intersection, false, false)
+}
+
+// Difference sets g to g1 &^ g2, with the same length
+// semantics as Union.
+func (g *Growable) Difference(g1, g2 *Growable) {
+	g.combine(g1, g2, Bitset.Difference, true, false)
+}
+
+// SymmetricDifference sets g to g1 ^ g2, with the same
+// length semantics as Union.
+func (g *Growable) SymmetricDifference(g1, g2 *Growable) {
+	g.combine(g1, g2, Bitset.SymmetricDifference, true, true)
+}
+
+// combine applies op over the common bytes of g1 and g2.
+// keep1 and keep2 report whether bits only in the
+// longer of g1 or g2 respectively are kept.
+func (g *Growable) combine(g1, g2 *Growable, op func(b, b1, b2 Bitset), keep1, keep2 bool) {
+	n1, n2 := g1.n, g2.n
+
+	n := n1
+	if n2 > n {
+		n = n2
+	}
+
+	// g may be g1 or g2, so resizing extends that
+	// operand with clear bits as well.
+	g.Resize(n)
+
+	b1, b2 := g1.b[:(n1+7)>>3], g2.b[:(n2+7)>>3]
+
+	m := len(b1)
+	if len(b2) < m {
+		m = len(b2)
+	}
+
+	switch {
+	case len(b1) > m && keep1:
+		copy(g.b[m:], b1[m:])
+	case len(b2) > m && keep2:
+		copy(g.b[m:], b2[m:])
+	default:
+		g.b[m:].ClearAll()
+	}
+
+	op(g.b[:m], b1[:m], b2[:m])
+}
@@ -0,0 +1,185 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestGrowableSet(t *testing.T) {
+	g := NewGrowable(0)
+
+	g.Set(100)
+
+	if g.Len() != 101 || !g.IsSet(100) || g.Count() != 1 {
+		t.Errorf("Set failed to grow, got %s", g)
+	}
+
+	if g.IsSet(1000) || !g.IsClear(1000) {
+		t.Error("IsSet failed, bits past Len should be clear")
+	}
+
+	g.Clear(1000)
+	g.ClearRange(50, 2000)
+	g.SetTo(2000, false)
+
+	if g.Len() != 101 || g.Any() {
+		t.Errorf("Clear failed, should not grow, got %s", g)
+	}
+
+	g.SetRange(200, 300)
+	g.InvertRange(250, 350)
+
+	if g.Len() != 350 || g.Count() != 100 || !g.IsRangeSet(200, 250) || !g.IsRangeSet(300, 350) {
+		t.Errorf("SetRange failed to grow, got %s", g)
+	}
+}
+
+func TestGrowableMaxLen(t *testing.T) {
+	g := NewGrowable(8)
+	g.SetMaxLen(64)
+
+	g.Set(63)
+	g.AppendBits(0, 0)
+
+	for _, fn := range []func(){
+		func() { g.Set(64) },
+		func() { g.AppendBit(true) },
+		func() { g.SetRange(0, 65) },
+		func() { g.Resize(65) },
+		func() { g.SetMaxLen(63) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic when growing past MaxLen")
+				}
+			}()
+
+			fn()
+		}()
+	}
+
+	if g.Len() != 64 || g.MaxLen() != 64 {
+		t.Errorf("MaxLen failed, got %s", g)
+	}
+}
+
+func TestGrowableOverflow(t *testing.T) {
+	g := NewGrowable(8)
+
+	for _, fn := range []func(){
+		func() { g.Set(^uint(0)) },
+		func() { g.Invert(^uint(0)) },
+		func() { g.SetTo(^uint(0), true) },
+		func() { g.SetRange(0, ^uint(0)) },
+		func() { g.PutBits(^uint(0)-8, 8, 0xff) },
+	} {
+		func() {
+			defer func() {
+				if err := recover(); err != errOutOfRange {
+					t.Errorf("expected panic with %v, got %v", errOutOfRange, err)
+				}
+			}()
+
+			fn()
+		}()
+	}
+
+	if g.Len() != 8 {
+		t.Errorf("Growable was modified by failed call, got %s", g)
+	}
+}
+
+func growableBitwiseTestValues(args []reflect.Value, rand *rand.Rand) {
+	for i := 0; i < 2; i++ {
+		b := New(uint(rand.Intn(1024)))
+		rand.Read(b)
+
+		args[i] = reflect.ValueOf(&Growable{Sized: *NewSizedFromBitset(b, uint(rand.Intn(int(b.Len())+1)))})
+	}
+}
+
+func TestGrowableBitwise(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(g, g1, g2 *Growable)
+		fn1  func(a, b bool) bool
+	}{
+		{"Union", (*Growable).Union, func(a, b bool) bool { return a || b }},
+		{"Intersection", (*Growable).Intersection, func(a, b bool) bool { return a && b }},
+		{"Difference", (*Growable).Difference, func(a, b bool) bool { return a && !b }},
+		{"SymmetricDifference", (*Growable).SymmetricDifference, func(a, b bool) bool { return a != b }},
+	} {
+		check := func(g1, g2 *Growable) bool {
+			n := g1.Len()
+			if g2.Len() > n {
+				n = g2.Len()
+			}
+
+			for _, g := range []*Growable{NewGrowable(3), g1.Clone(), g2.Clone()} {
+				g1, g2 := g1.Clone(), g2.Clone()
+				if g.Len() == g1.Len() && g.Equal(g1) {
+					g1 = g
+				} else if g.Len() == g2.Len() && g.Equal(g2) {
+					g2 = g
+				}
+
+				exp := make([]bool, n)
+				for i := range exp {
+					exp[i] = v.fn1(g1.IsSet(uint(i)), g2.IsSet(uint(i)))
+				}
+
+				v.fn(g, g1, g2)
+
+				if g.Len() != n {
+					return false
+				}
+
+				for i := range exp {
+					if g.IsSet(uint(i)) != exp[i] {
+						return false
+					}
+				}
+			}
+
+			return true
+		}
+
+		if err := quick.Check(func(g1, g2 *Growable) bool {
+			return check(g1, g2) && check(g2, g1)
+		}, &quick.Config{
+			Values:        growableBitwiseTestValues,
+			MaxCountScale: 10,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestGrowableBits(t *testing.T) {
+	g := NewGrowable(0)
+	g.PutSignedBits(100, 12, -2)
+
+	if g.Len() != 112 {
+		t.Errorf("PutBits failed to grow, got %s", g)
+	}
+
+	if x := g.GetSignedBits(100, 12); x != -2 {
+		t.Errorf("GetSignedBits failed, expected -2, got %d", x)
+	}
+
+	if x := g.GetBits(104, 64); x != 0xff {
+		t.Errorf("GetBits failed past Len, expected 0xff, got %#x", x)
+	}
+
+	if x := g.GetBits(1000, 64); x != 0 {
+		t.Errorf("GetBits failed past Len, expected 0, got %#x", x)
+	}
+}
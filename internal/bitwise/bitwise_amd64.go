@@ -5,7 +5,9 @@
 
 // +build amd64,!gccgo,!appengine
 
-// Package bitwise provides an efficient implementation of a & b == b.
+// Package bitwise provides efficient implementations of a & b == b,
+// of a & b != 0, of multi-input AND and OR, and of counting the bits
+// set in the result of a bitwise operation.
 package bitwise
 
 // AndEq returns true iff a & b == b
@@ -22,6 +24,56 @@ func AndEq(a, b []byte) bool {
 	return andeqASM(&a[0], &b[0], uint64(n))
 }
 
+// AndAny returns true iff a & b != 0
+func AndAny(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	if n == 0 {
+		return false
+	}
+
+	return andanyASM(&a[0], &b[0], uint64(n))
+}
+
+// OrN sets each element in dst according to
+// dst[i] = srcs[0][i] OR srcs[1][i] OR ... srcs[n-1][i]
+func OrN(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+	if n == 0 {
+		return 0
+	}
+
+	ornASM(&dst[0], &srcs[0], uint64(len(srcs)), uint64(n))
+	return n
+}
+
+// AndN sets each element in dst according to
+// dst[i] = srcs[0][i] AND srcs[1][i] AND ... srcs[n-1][i]
+func AndN(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+	if n == 0 {
+		return 0
+	}
+
+	andnASM(&dst[0], &srcs[0], uint64(len(srcs)), uint64(n))
+	return n
+}
+
 // This function is implemented in bitwise_andeq_amd64.s
 //go:noescape
 func andeqASM(a, b *byte, len uint64) (ret bool)
+
+// This function is implemented in bitwise_andany_amd64.s
+//go:noescape
+func andanyASM(a, b *byte, len uint64) (ret bool)
+
+// This function is implemented in bitwise_n_amd64.s
+//go:noescape
+func ornASM(dst *byte, srcs *[]byte, nsrcs, len uint64)
+
+// This function is implemented in bitwise_n_amd64.s
+//go:noescape
+func andnASM(dst *byte, srcs *[]byte, nsrcs, len uint64)
@@ -0,0 +1,123 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitwise
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+func minLen(a, b []byte) int {
+	if len(b) < len(a) {
+		return len(b)
+	}
+
+	return len(a)
+}
+
+func andCountGo(a, b []byte) uint64 {
+	n := minLen(a, b)
+
+	var count int
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(a[i:])
+		y := binary.LittleEndian.Uint64(b[i:])
+		count += bits.OnesCount64(x & y)
+	}
+
+	for ; i < n; i++ {
+		x, y := a[i], b[i]
+		count += bits.OnesCount8(x & y)
+	}
+
+	return uint64(count)
+}
+
+func orCountGo(a, b []byte) uint64 {
+	n := minLen(a, b)
+
+	var count int
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(a[i:])
+		y := binary.LittleEndian.Uint64(b[i:])
+		count += bits.OnesCount64(x | y)
+	}
+
+	for ; i < n; i++ {
+		x, y := a[i], b[i]
+		count += bits.OnesCount8(x | y)
+	}
+
+	return uint64(count)
+}
+
+func andNotCountGo(a, b []byte) uint64 {
+	n := minLen(a, b)
+
+	var count int
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(a[i:])
+		y := binary.LittleEndian.Uint64(b[i:])
+		count += bits.OnesCount64(x &^ y)
+	}
+
+	for ; i < n; i++ {
+		x, y := a[i], b[i]
+		count += bits.OnesCount8(x &^ y)
+	}
+
+	return uint64(count)
+}
+
+func xorCountGo(a, b []byte) uint64 {
+	n := minLen(a, b)
+
+	var count int
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(a[i:])
+		y := binary.LittleEndian.Uint64(b[i:])
+		count += bits.OnesCount64(x ^ y)
+	}
+
+	for ; i < n; i++ {
+		x, y := a[i], b[i]
+		count += bits.OnesCount8(x ^ y)
+	}
+
+	return uint64(count)
+}
+
+func andCountsGo(a, b []byte) (ca, cb, cand uint64) {
+	n := minLen(a, b)
+
+	var na, nb, nand int
+
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		x := binary.LittleEndian.Uint64(a[i:])
+		y := binary.LittleEndian.Uint64(b[i:])
+		na += bits.OnesCount64(x)
+		nb += bits.OnesCount64(y)
+		nand += bits.OnesCount64(x & y)
+	}
+
+	for ; i < n; i++ {
+		x, y := a[i], b[i]
+		na += bits.OnesCount8(x)
+		nb += bits.OnesCount8(y)
+		nand += bits.OnesCount8(x & y)
+	}
+
+	return uint64(na), uint64(nb), uint64(nand)
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+// +build amd64,!gccgo,!appengine
+
+package bitwise
+
+var usePOPCNT = hasPOPCNT()
+
+// AndCount returns the number of bits set in a AND b
+func AndCount(a, b []byte) uint64 {
+	n := minLen(a, b)
+	if n == 0 {
+		return 0
+	}
+
+	if !usePOPCNT {
+		return andCountGo(a, b)
+	}
+
+	return andCountASM(&a[0], &b[0], uint64(n))
+}
+
+// OrCount returns the number of bits set in a OR b
+func OrCount(a, b []byte) uint64 {
+	n := minLen(a, b)
+	if n == 0 {
+		return 0
+	}
+
+	if !usePOPCNT {
+		return orCountGo(a, b)
+	}
+
+	return orCountASM(&a[0], &b[0], uint64(n))
+}
+
+// AndNotCount returns the number of bits set in a AND NOT b
+func AndNotCount(a, b []byte) uint64 {
+	n := minLen(a, b)
+	if n == 0 {
+		return 0
+	}
+
+	if !usePOPCNT {
+		return andNotCountGo(a, b)
+	}
+
+	return andNotCountASM(&a[0], &b[0], uint64(n))
+}
+
+// XORCount returns the number of bits set in a XOR b
+func XORCount(a, b []byte) uint64 {
+	n := minLen(a, b)
+	if n == 0 {
+		return 0
+	}
+
+	if !usePOPCNT {
+		return xorCountGo(a, b)
+	}
+
+	return xorCountASM(&a[0], &b[0], uint64(n))
+}
+
+// AndCounts returns the number of bits set in a, in b and
+// in a AND b in a single pass
+func AndCounts(a, b []byte) (ca, cb, cand uint64) {
+	n := minLen(a, b)
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	if !usePOPCNT {
+		return andCountsGo(a, b)
+	}
+
+	return andCountsASM(&a[0], &b[0], uint64(n))
+}
+
+// This function is implemented in bitwise_popcnt_amd64.s
+//go:noescape
+func hasPOPCNT() (ret bool)
+
+// This function is implemented in bitwise_count_amd64.s
+//go:noescape
+func andCountASM(a, b *byte, len uint64) (ret uint64)
+
+// This function is implemented in bitwise_count_amd64.s
+//go:noescape
+func orCountASM(a, b *byte, len uint64) (ret uint64)
+
+// This function is implemented in bitwise_count_amd64.s
+//go:noescape
+func andNotCountASM(a, b *byte, len uint64) (ret uint64)
+
+// This function is implemented in bitwise_count_amd64.s
+//go:noescape
+func xorCountASM(a, b *byte, len uint64) (ret uint64)
+
+// This function is implemented in bitwise_count_amd64.s
+//go:noescape
+func andCountsASM(a, b *byte, len uint64) (ca, cb, cand uint64)
@@ -0,0 +1,350 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+// +build amd64,!gccgo,!appengine
+
+#include "textflag.h"
+
+// See the go-popcount package for an explanation of why
+// four separate destination registers are used in bigloop
+// and why DX is cleared before POPCNT in loop.
+
+TEXT ·andCountASM(SB),NOSPLIT,$0
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DI
+	MOVQ len+16(FP), BX
+
+	XORQ AX, AX
+
+	CMPQ BX, $8
+	JB tail
+
+	CMPQ BX, $32
+	JB loop
+
+bigloop:
+	MOVQ -8(SI)(BX*1), R8
+	ANDQ -8(DI)(BX*1), R8
+	MOVQ -16(SI)(BX*1), R9
+	ANDQ -16(DI)(BX*1), R9
+	MOVQ -24(SI)(BX*1), R10
+	ANDQ -24(DI)(BX*1), R10
+	MOVQ -32(SI)(BX*1), R11
+	ANDQ -32(DI)(BX*1), R11
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+	POPCNTQ R11, R11
+	ADDQ R8, AX
+	ADDQ R9, AX
+	ADDQ R10, AX
+	ADDQ R11, AX
+
+	SUBQ $32, BX
+	JZ ret
+
+	CMPQ BX, $32
+	JAE bigloop
+
+	CMPQ BX, $8
+	JB tail
+
+loop:
+	MOVQ -8(SI)(BX*1), R8
+	ANDQ -8(DI)(BX*1), R8
+	XORQ DX, DX
+	POPCNTQ R8, DX
+	ADDQ DX, AX
+
+	SUBQ $8, BX
+	JZ ret
+
+	CMPQ BX, $8
+	JAE loop
+
+tail:
+	MOVBQZX -1(SI)(BX*1), DX
+	MOVBQZX -1(DI)(BX*1), R8
+	ANDQ R8, DX
+	ANDQ $0xff, DX
+	POPCNTQ DX, DX
+	ADDQ DX, AX
+
+	SUBQ $1, BX
+	JNZ tail
+
+ret:
+	MOVQ AX, ret+24(FP)
+	RET
+
+TEXT ·orCountASM(SB),NOSPLIT,$0
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DI
+	MOVQ len+16(FP), BX
+
+	XORQ AX, AX
+
+	CMPQ BX, $8
+	JB tail
+
+	CMPQ BX, $32
+	JB loop
+
+bigloop:
+	MOVQ -8(SI)(BX*1), R8
+	ORQ -8(DI)(BX*1), R8
+	MOVQ -16(SI)(BX*1), R9
+	ORQ -16(DI)(BX*1), R9
+	MOVQ -24(SI)(BX*1), R10
+	ORQ -24(DI)(BX*1), R10
+	MOVQ -32(SI)(BX*1), R11
+	ORQ -32(DI)(BX*1), R11
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+	POPCNTQ R11, R11
+	ADDQ R8, AX
+	ADDQ R9, AX
+	ADDQ R10, AX
+	ADDQ R11, AX
+
+	SUBQ $32, BX
+	JZ ret
+
+	CMPQ BX, $32
+	JAE bigloop
+
+	CMPQ BX, $8
+	JB tail
+
+loop:
+	MOVQ -8(SI)(BX*1), R8
+	ORQ -8(DI)(BX*1), R8
+	XORQ DX, DX
+	POPCNTQ R8, DX
+	ADDQ DX, AX
+
+	SUBQ $8, BX
+	JZ ret
+
+	CMPQ BX, $8
+	JAE loop
+
+tail:
+	MOVBQZX -1(SI)(BX*1), DX
+	MOVBQZX -1(DI)(BX*1), R8
+	ORQ R8, DX
+	ANDQ $0xff, DX
+	POPCNTQ DX, DX
+	ADDQ DX, AX
+
+	SUBQ $1, BX
+	JNZ tail
+
+ret:
+	MOVQ AX, ret+24(FP)
+	RET
+
+TEXT ·andNotCountASM(SB),NOSPLIT,$0
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DI
+	MOVQ len+16(FP), BX
+
+	XORQ AX, AX
+
+	CMPQ BX, $8
+	JB tail
+
+	CMPQ BX, $32
+	JB loop
+
+bigloop:
+	MOVQ -8(SI)(BX*1), R8
+	MOVQ -8(DI)(BX*1), R12
+	NOTQ R12
+	ANDQ R12, R8
+	MOVQ -16(SI)(BX*1), R9
+	MOVQ -16(DI)(BX*1), R12
+	NOTQ R12
+	ANDQ R12, R9
+	MOVQ -24(SI)(BX*1), R10
+	MOVQ -24(DI)(BX*1), R12
+	NOTQ R12
+	ANDQ R12, R10
+	MOVQ -32(SI)(BX*1), R11
+	MOVQ -32(DI)(BX*1), R12
+	NOTQ R12
+	ANDQ R12, R11
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+	POPCNTQ R11, R11
+	ADDQ R8, AX
+	ADDQ R9, AX
+	ADDQ R10, AX
+	ADDQ R11, AX
+
+	SUBQ $32, BX
+	JZ ret
+
+	CMPQ BX, $32
+	JAE bigloop
+
+	CMPQ BX, $8
+	JB tail
+
+loop:
+	MOVQ -8(SI)(BX*1), R8
+	MOVQ -8(DI)(BX*1), R12
+	NOTQ R12
+	ANDQ R12, R8
+	XORQ DX, DX
+	POPCNTQ R8, DX
+	ADDQ DX, AX
+
+	SUBQ $8, BX
+	JZ ret
+
+	CMPQ BX, $8
+	JAE loop
+
+tail:
+	MOVBQZX -1(SI)(BX*1), DX
+	MOVBQZX -1(DI)(BX*1), R8
+	NOTQ R8
+	ANDQ R8, DX
+	ANDQ $0xff, DX
+	POPCNTQ DX, DX
+	ADDQ DX, AX
+
+	SUBQ $1, BX
+	JNZ tail
+
+ret:
+	MOVQ AX, ret+24(FP)
+	RET
+
+TEXT ·xorCountASM(SB),NOSPLIT,$0
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DI
+	MOVQ len+16(FP), BX
+
+	XORQ AX, AX
+
+	CMPQ BX, $8
+	JB tail
+
+	CMPQ BX, $32
+	JB loop
+
+bigloop:
+	MOVQ -8(SI)(BX*1), R8
+	XORQ -8(DI)(BX*1), R8
+	MOVQ -16(SI)(BX*1), R9
+	XORQ -16(DI)(BX*1), R9
+	MOVQ -24(SI)(BX*1), R10
+	XORQ -24(DI)(BX*1), R10
+	MOVQ -32(SI)(BX*1), R11
+	XORQ -32(DI)(BX*1), R11
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+	POPCNTQ R11, R11
+	ADDQ R8, AX
+	ADDQ R9, AX
+	ADDQ R10, AX
+	ADDQ R11, AX
+
+	SUBQ $32, BX
+	JZ ret
+
+	CMPQ BX, $32
+	JAE bigloop
+
+	CMPQ BX, $8
+	JB tail
+
+loop:
+	MOVQ -8(SI)(BX*1), R8
+	XORQ -8(DI)(BX*1), R8
+	XORQ DX, DX
+	POPCNTQ R8, DX
+	ADDQ DX, AX
+
+	SUBQ $8, BX
+	JZ ret
+
+	CMPQ BX, $8
+	JAE loop
+
+tail:
+	MOVBQZX -1(SI)(BX*1), DX
+	MOVBQZX -1(DI)(BX*1), R8
+	XORQ R8, DX
+	ANDQ $0xff, DX
+	POPCNTQ DX, DX
+	ADDQ DX, AX
+
+	SUBQ $1, BX
+	JNZ tail
+
+ret:
+	MOVQ AX, ret+24(FP)
+	RET
+
+TEXT ·andCountsASM(SB),NOSPLIT,$0
+	MOVQ a+0(FP), SI
+	MOVQ b+8(FP), DI
+	MOVQ len+16(FP), BX
+
+	XORQ AX, AX
+	XORQ CX, CX
+	XORQ DX, DX
+
+	CMPQ BX, $8
+	JB tail
+
+loop:
+	MOVQ -8(SI)(BX*1), R8
+	MOVQ -8(DI)(BX*1), R9
+	MOVQ R8, R10
+	ANDQ R9, R10
+
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+
+	ADDQ R8, AX
+	ADDQ R9, CX
+	ADDQ R10, DX
+
+	SUBQ $8, BX
+	JZ ret
+
+	CMPQ BX, $8
+	JAE loop
+
+tail:
+	MOVBQZX -1(SI)(BX*1), R8
+	MOVBQZX -1(DI)(BX*1), R9
+	MOVQ R8, R10
+	ANDQ R9, R10
+
+	POPCNTQ R8, R8
+	POPCNTQ R9, R9
+	POPCNTQ R10, R10
+
+	ADDQ R8, AX
+	ADDQ R9, CX
+	ADDQ R10, DX
+
+	SUBQ $1, BX
+	JNZ tail
+
+ret:
+	MOVQ AX, ca+24(FP)
+	MOVQ CX, cb+32(FP)
+	MOVQ DX, cand+40(FP)
+	RET
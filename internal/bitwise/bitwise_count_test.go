@@ -0,0 +1,136 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitwise
+
+import (
+	"math/bits"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+var countOps = []struct {
+	name string
+	fn   func(a, b []byte) uint64
+	goFn func(a, b []byte) uint64
+	op   func(x, y byte) byte
+}{
+	{"AndCount", AndCount, andCountGo, func(x, y byte) byte { return x & y }},
+	{"OrCount", OrCount, orCountGo, func(x, y byte) byte { return x | y }},
+	{"AndNotCount", AndNotCount, andNotCountGo, func(x, y byte) byte { return x &^ y }},
+	{"XORCount", XORCount, xorCountGo, func(x, y byte) byte { return x ^ y }},
+}
+
+func testCountBytes(op func(x, y byte) byte) func(a, b []byte) uint64 {
+	return func(a, b []byte) uint64 {
+		var count int
+		for i := 0; i < len(a) && i < len(b); i++ {
+			count += bits.OnesCount8(op(a[i], b[i]))
+		}
+
+		return uint64(count)
+	}
+}
+
+func TestCount(t *testing.T) {
+	for _, op := range countOps {
+		testFn := testCountBytes(op.op)
+
+		for alignP := 0; alignP < 2; alignP++ {
+			for alignQ := 0; alignQ < 2; alignQ++ {
+				p := make([]byte, 1024)[alignP:]
+				rand.Read(p)
+
+				q := make([]byte, 1024)[alignQ:]
+				rand.Read(q)
+
+				if op.fn(p, q) != testFn(p, q) {
+					t.Errorf("%s failed with alignment %d, %d", op.name, alignP, alignQ)
+				}
+			}
+		}
+
+		if err := quick.CheckEqual(op.fn, testFn, &quick.Config{
+			MaxCountScale: 500,
+		}); err != nil {
+			t.Errorf("%s failed: %v", op.name, err)
+		}
+
+		if err := quick.CheckEqual(op.goFn, testFn, &quick.Config{
+			MaxCountScale: 500,
+		}); err != nil {
+			t.Errorf("%s (Go) failed: %v", op.name, err)
+		}
+	}
+}
+
+func TestAndCounts(t *testing.T) {
+	testFn := func(a, b []byte) [3]uint64 {
+		return [3]uint64{
+			testCountBytes(func(x, y byte) byte { return x })(a, b),
+			testCountBytes(func(x, y byte) byte { return y })(a, b),
+			testCountBytes(func(x, y byte) byte { return x & y })(a, b),
+		}
+	}
+
+	for _, fn := range []func(a, b []byte) (ca, cb, cand uint64){AndCounts, andCountsGo} {
+		if err := quick.CheckEqual(func(a, b []byte) [3]uint64 {
+			ca, cb, cand := fn(a, b)
+			return [3]uint64{ca, cb, cand}
+		}, testFn, &quick.Config{
+			MaxCountScale: 500,
+		}); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func benchmarkCount(b *testing.B, testFn func(a, b []byte) uint64) {
+	maxSize := benchSizes[len(benchSizes)-1]
+
+	p, q := make([]byte, maxSize.l), make([]byte, maxSize.l)
+	rand.Read(p)
+	rand.Read(q)
+
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			b.SetBytes(int64(size.l))
+
+			p, q := p[:size.l], q[:size.l]
+
+			for i := 0; i < b.N; i++ {
+				testFn(p, q)
+			}
+		})
+	}
+}
+
+func BenchmarkAndCount(b *testing.B) {
+	benchmarkCount(b, AndCount)
+}
+
+func BenchmarkAndCountGo(b *testing.B) {
+	benchmarkCount(b, andCountGo)
+}
+
+func BenchmarkOrCount(b *testing.B) {
+	benchmarkCount(b, OrCount)
+}
+
+func BenchmarkAndNotCount(b *testing.B) {
+	benchmarkCount(b, AndNotCount)
+}
+
+func BenchmarkXORCount(b *testing.B) {
+	benchmarkCount(b, XORCount)
+}
+
+func BenchmarkAndCounts(b *testing.B) {
+	benchmarkCount(b, func(a, b []byte) uint64 {
+		ca, cb, cand := AndCounts(a, b)
+		return ca + cb + cand
+	})
+}
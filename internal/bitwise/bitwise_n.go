@@ -0,0 +1,21 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitwise
+
+func minLenN(dst []byte, srcs [][]byte) int {
+	if len(srcs) == 0 {
+		return 0
+	}
+
+	n := len(dst)
+	for _, src := range srcs {
+		if len(src) < n {
+			n = len(src)
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+// +build amd64,!gccgo,!appengine
+
+#include "textflag.h"
+
+TEXT ·ornASM(SB),NOSPLIT,$0
+	MOVQ dst+0(FP), DI
+	MOVQ srcs+8(FP), SI
+	MOVQ nsrcs+16(FP), CX
+	MOVQ len+24(FP), BX
+
+	CMPQ BX, $16
+	JB loop
+
+	CMPQ BX, $64
+	JB bigloop
+
+hugeloop:
+	MOVQ 0(SI), R8
+	MOVOU -16(R8)(BX*1), X0
+	MOVOU -32(R8)(BX*1), X1
+	MOVOU -48(R8)(BX*1), X2
+	MOVOU -64(R8)(BX*1), X3
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+hugeloop_srcs:
+	CMPQ DX, CX
+	JAE hugeloop_store
+
+	MOVQ 0(R9), R8
+	MOVOU -16(R8)(BX*1), X4
+	MOVOU -32(R8)(BX*1), X5
+	MOVOU -48(R8)(BX*1), X6
+	MOVOU -64(R8)(BX*1), X7
+	POR X4, X0
+	POR X5, X1
+	POR X6, X2
+	POR X7, X3
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP hugeloop_srcs
+
+hugeloop_store:
+	MOVOU X0, -16(DI)(BX*1)
+	MOVOU X1, -32(DI)(BX*1)
+	MOVOU X2, -48(DI)(BX*1)
+	MOVOU X3, -64(DI)(BX*1)
+
+	SUBQ $64, BX
+	JZ ret
+
+	CMPQ BX, $64
+	JAE hugeloop
+
+	CMPQ BX, $16
+	JB loop
+
+bigloop:
+	MOVQ 0(SI), R8
+	MOVOU -16(R8)(BX*1), X0
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+bigloop_srcs:
+	CMPQ DX, CX
+	JAE bigloop_store
+
+	MOVQ 0(R9), R8
+	MOVOU -16(R8)(BX*1), X1
+	POR X1, X0
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP bigloop_srcs
+
+bigloop_store:
+	MOVOU X0, -16(DI)(BX*1)
+
+	SUBQ $16, BX
+	JZ ret
+
+	CMPQ BX, $16
+	JAE bigloop
+
+loop:
+	MOVQ 0(SI), R8
+	MOVB -1(R8)(BX*1), AX
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+loop_srcs:
+	CMPQ DX, CX
+	JAE loop_store
+
+	MOVQ 0(R9), R8
+	ORB -1(R8)(BX*1), AX
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP loop_srcs
+
+loop_store:
+	MOVB AX, -1(DI)(BX*1)
+
+	SUBQ $1, BX
+	JNZ loop
+
+ret:
+	RET
+
+TEXT ·andnASM(SB),NOSPLIT,$0
+	MOVQ dst+0(FP), DI
+	MOVQ srcs+8(FP), SI
+	MOVQ nsrcs+16(FP), CX
+	MOVQ len+24(FP), BX
+
+	CMPQ BX, $16
+	JB loop
+
+	CMPQ BX, $64
+	JB bigloop
+
+hugeloop:
+	MOVQ 0(SI), R8
+	MOVOU -16(R8)(BX*1), X0
+	MOVOU -32(R8)(BX*1), X1
+	MOVOU -48(R8)(BX*1), X2
+	MOVOU -64(R8)(BX*1), X3
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+hugeloop_srcs:
+	CMPQ DX, CX
+	JAE hugeloop_store
+
+	MOVQ 0(R9), R8
+	MOVOU -16(R8)(BX*1), X4
+	MOVOU -32(R8)(BX*1), X5
+	MOVOU -48(R8)(BX*1), X6
+	MOVOU -64(R8)(BX*1), X7
+	PAND X4, X0
+	PAND X5, X1
+	PAND X6, X2
+	PAND X7, X3
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP hugeloop_srcs
+
+hugeloop_store:
+	MOVOU X0, -16(DI)(BX*1)
+	MOVOU X1, -32(DI)(BX*1)
+	MOVOU X2, -48(DI)(BX*1)
+	MOVOU X3, -64(DI)(BX*1)
+
+	SUBQ $64, BX
+	JZ ret
+
+	CMPQ BX, $64
+	JAE hugeloop
+
+	CMPQ BX, $16
+	JB loop
+
+bigloop:
+	MOVQ 0(SI), R8
+	MOVOU -16(R8)(BX*1), X0
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+bigloop_srcs:
+	CMPQ DX, CX
+	JAE bigloop_store
+
+	MOVQ 0(R9), R8
+	MOVOU -16(R8)(BX*1), X1
+	PAND X1, X0
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP bigloop_srcs
+
+bigloop_store:
+	MOVOU X0, -16(DI)(BX*1)
+
+	SUBQ $16, BX
+	JZ ret
+
+	CMPQ BX, $16
+	JAE bigloop
+
+loop:
+	MOVQ 0(SI), R8
+	MOVB -1(R8)(BX*1), AX
+
+	MOVQ $1, DX
+	LEAQ 24(SI), R9
+
+loop_srcs:
+	CMPQ DX, CX
+	JAE loop_store
+
+	MOVQ 0(R9), R8
+	ANDB -1(R8)(BX*1), AX
+
+	ADDQ $24, R9
+	INCQ DX
+	JMP loop_srcs
+
+loop_store:
+	MOVB AX, -1(DI)(BX*1)
+
+	SUBQ $1, BX
+	JNZ loop
+
+ret:
+	RET
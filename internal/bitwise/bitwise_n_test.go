@@ -0,0 +1,140 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitwise
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func testOrNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+
+	for i := 0; i < n; i++ {
+		v := byte(0)
+		for _, src := range srcs {
+			v |= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+func testAndNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+
+	for i := 0; i < n; i++ {
+		v := byte(0xff)
+		for _, src := range srcs {
+			v &= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+func testN(t *testing.T, name string, fn, testFn func(dst []byte, srcs [][]byte) int) {
+	for align := 0; align < 2; align++ {
+		for n := 1; n < 6; n++ {
+			srcs := make([][]byte, n)
+			for i := range srcs {
+				srcs[i] = make([]byte, 1024)[(i+align)&1:]
+				rand.Read(srcs[i])
+			}
+
+			d1, d2 := make([]byte, 1024)[align:], make([]byte, 1024)[align:]
+
+			if fn(d1, srcs) != testFn(d2, srcs) || !bytes.Equal(d1, d2) {
+				t.Errorf("%s failed with %d sources and alignment %d", name, n, align)
+			}
+		}
+	}
+
+	if err := quick.Check(func(a, b, c []byte) bool {
+		srcs := [][]byte{a, b, c}
+		d1, d2 := make([]byte, len(a)), make([]byte, len(a))
+		return fn(d1, srcs) == testFn(d2, srcs) && bytes.Equal(d1, d2)
+	}, &quick.Config{
+		MaxCountScale: 500,
+	}); err != nil {
+		t.Errorf("%s failed: %v", name, err)
+	}
+
+	if err := quick.Check(func(a, b []byte) bool {
+		d := append([]byte(nil), b...)
+		testFn(d, [][]byte{a, d})
+
+		b1 := append([]byte(nil), b...)
+		fn(b1, [][]byte{a, b1})
+
+		return bytes.Equal(d, b1)
+	}, &quick.Config{
+		MaxCountScale: 500,
+	}); err != nil {
+		t.Errorf("%s failed when aliased: %v", name, err)
+	}
+
+	if fn(make([]byte, 10), nil) != 0 {
+		t.Errorf("%s failed with no sources", name)
+	}
+}
+
+func TestOrN(t *testing.T) {
+	testN(t, "OrN", OrN, testOrNBytes)
+}
+
+func TestAndN(t *testing.T) {
+	testN(t, "AndN", AndN, testAndNBytes)
+}
+
+func benchmarkN(b *testing.B, testFn func(dst []byte, srcs [][]byte) int) {
+	maxSize := benchSizes[len(benchSizes)-1]
+
+	srcs := make([][]byte, 8)
+	for i := range srcs {
+		srcs[i] = make([]byte, maxSize.l)
+		rand.Read(srcs[i])
+	}
+
+	dst := make([]byte, maxSize.l)
+
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			b.SetBytes(int64(size.l * len(srcs)))
+
+			srcs := append([][]byte(nil), srcs...)
+			for i := range srcs {
+				srcs[i] = srcs[i][:size.l]
+			}
+
+			for i := 0; i < b.N; i++ {
+				testFn(dst[:size.l], srcs)
+			}
+		})
+	}
+}
+
+func BenchmarkOrN(b *testing.B) {
+	benchmarkN(b, OrN)
+}
+
+func BenchmarkOrNGo(b *testing.B) {
+	benchmarkN(b, testOrNBytes)
+}
+
+func BenchmarkAndN(b *testing.B) {
+	benchmarkN(b, AndN)
+}
+
+func BenchmarkAndNGo(b *testing.B) {
+	benchmarkN(b, testAndNBytes)
+}
@@ -4,7 +4,9 @@
 
 // +build !amd64 gccgo appengine
 
-// Package bitwise provides an efficient implementation of a & b == b.
+// Package bitwise provides efficient implementations of a & b == b,
+// of a & b != 0, of multi-input AND and OR, and of counting the bits
+// set in the result of a bitwise operation.
 package bitwise
 
 import (
@@ -67,3 +69,194 @@ func AndEq(a, b []byte) bool {
 	// we could still try fastAndEqBytes.
 	return safeAndEqBytes(a, b)
 }
+
+func fastAndAnyBytes(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	w := n / wordSize
+	if w > 0 {
+		aw := *(*[]uintptr)(unsafe.Pointer(&a))
+		bw := *(*[]uintptr)(unsafe.Pointer(&b))
+
+		for i := 0; i < w; i++ {
+			if aw[i]&bw[i] != 0 {
+				return true
+			}
+		}
+	}
+
+	for i := (n - n%wordSize); i < n; i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func safeAndAnyBytes(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	for i := 0; i < n; i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AndAny returns true iff a & b != 0
+func AndAny(a, b []byte) bool {
+	if supportsUnaligned {
+		return fastAndAnyBytes(a, b)
+	}
+
+	return safeAndAnyBytes(a, b)
+}
+
+func fastOrNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+	if n == 0 {
+		return 0
+	}
+
+	w := n / wordSize
+	if w > 0 {
+		dw := *(*[]uintptr)(unsafe.Pointer(&dst))
+
+		for i := 0; i < w; i++ {
+			v := (*(*[]uintptr)(unsafe.Pointer(&srcs[0])))[i]
+			for j := 1; j < len(srcs); j++ {
+				v |= (*(*[]uintptr)(unsafe.Pointer(&srcs[j])))[i]
+			}
+
+			dw[i] = v
+		}
+	}
+
+	for i := (n - n%wordSize); i < n; i++ {
+		v := srcs[0][i]
+		for _, src := range srcs[1:] {
+			v |= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+func safeOrNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+
+	for i := 0; i < n; i++ {
+		v := srcs[0][i]
+		for _, src := range srcs[1:] {
+			v |= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+// OrN sets each element in dst according to
+// dst[i] = srcs[0][i] OR srcs[1][i] OR ... srcs[n-1][i]
+func OrN(dst []byte, srcs [][]byte) int {
+	if supportsUnaligned {
+		return fastOrNBytes(dst, srcs)
+	}
+
+	return safeOrNBytes(dst, srcs)
+}
+
+func fastAndNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+	if n == 0 {
+		return 0
+	}
+
+	w := n / wordSize
+	if w > 0 {
+		dw := *(*[]uintptr)(unsafe.Pointer(&dst))
+
+		for i := 0; i < w; i++ {
+			v := (*(*[]uintptr)(unsafe.Pointer(&srcs[0])))[i]
+			for j := 1; j < len(srcs); j++ {
+				v &= (*(*[]uintptr)(unsafe.Pointer(&srcs[j])))[i]
+			}
+
+			dw[i] = v
+		}
+	}
+
+	for i := (n - n%wordSize); i < n; i++ {
+		v := srcs[0][i]
+		for _, src := range srcs[1:] {
+			v &= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+func safeAndNBytes(dst []byte, srcs [][]byte) int {
+	n := minLenN(dst, srcs)
+
+	for i := 0; i < n; i++ {
+		v := srcs[0][i]
+		for _, src := range srcs[1:] {
+			v &= src[i]
+		}
+
+		dst[i] = v
+	}
+
+	return n
+}
+
+// AndN sets each element in dst according to
+// dst[i] = srcs[0][i] AND srcs[1][i] AND ... srcs[n-1][i]
+func AndN(dst []byte, srcs [][]byte) int {
+	if supportsUnaligned {
+		return fastAndNBytes(dst, srcs)
+	}
+
+	return safeAndNBytes(dst, srcs)
+}
+
+// AndCount returns the number of bits set in a AND b
+func AndCount(a, b []byte) uint64 {
+	return andCountGo(a, b)
+}
+
+// OrCount returns the number of bits set in a OR b
+func OrCount(a, b []byte) uint64 {
+	return orCountGo(a, b)
+}
+
+// AndNotCount returns the number of bits set in a AND NOT b
+func AndNotCount(a, b []byte) uint64 {
+	return andNotCountGo(a, b)
+}
+
+// XORCount returns the number of bits set in a XOR b
+func XORCount(a, b []byte) uint64 {
+	return xorCountGo(a, b)
+}
+
+// AndCounts returns the number of bits set in a, in b and
+// in a AND b in a single pass
+func AndCounts(a, b []byte) (ca, cb, cand uint64) {
+	return andCountsGo(a, b)
+}
@@ -118,6 +118,71 @@ func TestAndEq(t *testing.T) {
 	}
 }
 
+func testAndAnyBytes(a, b []byte) bool {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	for i := 0; i < n; i++ {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestAndAny(t *testing.T) {
+	for i, vector := range andEqTestVectors {
+		if AndAny(vector.a, vector.b) != testAndAnyBytes(vector.a, vector.b) {
+			t.Errorf("test case #%d failed", i)
+		}
+	}
+
+	for alignP := 0; alignP < 2; alignP++ {
+		for alignQ := 0; alignQ < 2; alignQ++ {
+			p := make([]byte, 1024)[alignP:]
+			q := make([]byte, 1024)[alignQ:]
+
+			if AndAny(p, q) {
+				t.Error("AndAny failed, expected false")
+			}
+
+			p[500] = 0x10
+			q[500] = 0x30
+
+			if !AndAny(p, q) {
+				t.Error("AndAny failed, expected true")
+			}
+		}
+	}
+
+	if err := quick.CheckEqual(AndAny, testAndAnyBytes, &quick.Config{
+		MaxCountScale: 500,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	if err := quick.CheckEqual(func(a, b []byte) bool {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			b[i] &^= a[i]
+		}
+
+		return AndAny(a, b)
+	}, func(a, b []byte) bool {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			b[i] &^= a[i]
+		}
+
+		return testAndAnyBytes(a, b)
+	}, &quick.Config{
+		MaxCountScale: 500,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
 var benchSizes = []struct {
 	name string
 	l    int
@@ -164,3 +229,22 @@ func BenchmarkAndEqGo(b *testing.B) {
 func BenchmarkAndEqOther(b *testing.B) {
 	benchmarkThree(b, andEqBytesOther)
 }
+
+func BenchmarkAndAny(b *testing.B) {
+	maxSize := benchSizes[len(benchSizes)-1]
+
+	p, q := make([]byte, maxSize.l), make([]byte, maxSize.l)
+	rand.Read(q)
+
+	for _, size := range benchSizes {
+		b.Run(size.name, func(b *testing.B) {
+			b.SetBytes(int64(size.l))
+
+			p, q := p[:size.l], q[:size.l]
+
+			for i := 0; i < b.N; i++ {
+				AndAny(p, q)
+			}
+		})
+	}
+}
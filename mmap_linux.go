@@ -0,0 +1,242 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	errMappingReadOnly = errors.New("go-bitset: mapping is read-only")
+	errMappingClosed   = errors.New("go-bitset: mapping is closed")
+	errMappingTooLarge = errors.New("go-bitset: file too large to map")
+)
+
+// Mapping is a file mapped into memory that can be
+// viewed as either a Bitset or an Atomic.
+//
+// The length of a writable Mapping is always a
+// multiple of 64 bits, so both views cover the same
+// bits. Writes through either view are carried back to
+// the file and are seen by other processes mapping the
+// same file.
+type Mapping struct {
+	f    *os.File
+	data []byte
+
+	readOnly bool
+	closed   bool
+}
+
+// MapFile maps f into memory with room for at least size
+// bits, growing f if it is shorter. f must be open for
+// reading and writing.
+func MapFile(f *os.File, size uint) (*Mapping, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapping{f: f}
+
+	n := uint64(fi.Size())
+	if bytes := (uint64(size) + 7) / 8; bytes > n {
+		n = bytes
+	}
+
+	if err := m.mmap((n + 7) &^ 7); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// MapFileReadOnly maps the whole of f into memory for
+// reading only. Writing through either view of a
+// read-only Mapping faults.
+func MapFileReadOnly(f *os.File) (*Mapping, error) {
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapping{f: f, readOnly: true}
+
+	if err := m.mmap(uint64(fi.Size())); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Mapping) mmap(size uint64) error {
+	if size > uint64(^uint(0)>>1) {
+		return errMappingTooLarge
+	}
+
+	prot := syscall.PROT_READ
+	if !m.readOnly {
+		prot |= syscall.PROT_WRITE
+
+		fi, err := m.f.Stat()
+		if err != nil {
+			return err
+		}
+
+		if uint64(fi.Size()) < size {
+			if err := m.f.Truncate(int64(size)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if size == 0 {
+		m.data = nil
+		return nil
+	}
+
+	data, err := syscall.Mmap(int(m.f.Fd()), 0, int(size), prot, syscall.MAP_SHARED)
+	if err != nil {
+		return &os.PathError{Op: "mmap", Path: m.f.Name(), Err: err}
+	}
+
+	m.data = data
+	return nil
+}
+
+func (m *Mapping) munmap() error {
+	if m.data == nil {
+		return nil
+	}
+
+	if err := syscall.Munmap(m.data); err != nil {
+		return &os.PathError{Op: "munmap", Path: m.f.Name(), Err: err}
+	}
+
+	m.data = nil
+	return nil
+}
+
+func (m *Mapping) msync(flags int) error {
+	if m.closed {
+		return errMappingClosed
+	}
+
+	if len(m.data) == 0 {
+		return nil
+	}
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_MSYNC,
+		uintptr(unsafe.Pointer(&m.data[0])), uintptr(len(m.data)),
+		uintptr(flags)); errno != 0 {
+		return &os.PathError{Op: "msync", Path: m.f.Name(), Err: errno}
+	}
+
+	return nil
+}
+
+func (m *Mapping) Len() uint {
+	return uint(len(m.data)) * 8
+}
+
+func (m *Mapping) ReadOnly() bool {
+	return m.readOnly
+}
+
+// Bitset returns a Bitset viewing the mapped memory. It
+// is invalidated by Grow and Close.
+func (m *Mapping) Bitset() Bitset {
+	return m.data
+}
+
+// Atomic returns an Atomic viewing the mapped memory. It
+// is invalidated by Grow and Close.
+//
+// For a read-only Mapping whose file is not a multiple
+// of 8 bytes long, the trailing bytes are not included.
+func (m *Mapping) Atomic() Atomic {
+	if len(m.data) < 8 {
+		return nil
+	}
+
+	a := *(*Atomic)(unsafe.Pointer(&m.data))
+	return a[: len(m.data)/8 : len(m.data)/8]
+}
+
+// Sync writes any changes back to the file and waits
+// for the write to complete.
+func (m *Mapping) Sync() error {
+	return m.msync(syscall.MS_SYNC)
+}
+
+// Flush schedules any changes to be written back to the
+// file without waiting for the write to complete.
+func (m *Mapping) Flush() error {
+	return m.msync(syscall.MS_ASYNC)
+}
+
+// Grow extends the file and the mapping to hold at least
+// size bits. It does nothing if the Mapping is already
+// large enough.
+//
+// Grow remaps the file, so any Bitset or Atomic returned
+// before it is called must no longer be used. It is not
+// safe to call Grow concurrently with any other method
+// or with any use of those views.
+func (m *Mapping) Grow(size uint) error {
+	if m.closed {
+		return errMappingClosed
+	}
+
+	if m.readOnly {
+		return errMappingReadOnly
+	}
+
+	if size <= m.Len() {
+		return nil
+	}
+
+	if size > ^uint(0)&^63 {
+		return errMappingTooLarge
+	}
+
+	old := m.data
+
+	if err := m.mmap(uint64((size+63)&^63) / 8); err != nil {
+		m.data = old
+		return err
+	}
+
+	if len(m.data) == 0 {
+		m.data = old
+		return errMappingTooLarge
+	}
+
+	if old == nil {
+		return nil
+	}
+
+	if err := syscall.Munmap(old); err != nil {
+		return &os.PathError{Op: "munmap", Path: m.f.Name(), Err: err}
+	}
+
+	return nil
+}
+
+// Close unmaps the file. Changes not yet written back are
+// left for the kernel to write. It does not close the
+// underlying file.
+func (m *Mapping) Close() error {
+	if m.closed {
+		return errMappingClosed
+	}
+
+	m.closed = true
+	return m.munmap()
+}
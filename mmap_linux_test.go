@@ -0,0 +1,286 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func tempMapFile(t testing.TB) *os.File {
+	f, err := ioutil.TempFile("", "go-bitset")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	os.Remove(f.Name())
+	return f
+}
+
+func readMapFile(t testing.TB, f *os.File) []byte {
+	data, err := ioutil.ReadAll(io.NewSectionReader(f, 0, 1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestMapFile(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	m, err := MapFile(f, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if m.Len() != 1024 {
+		t.Fatalf("Len returned %d, expected 1024", m.Len())
+	}
+
+	if m.Atomic().Len() != m.Len() || m.Bitset().Len() != m.Len() {
+		t.Fatalf("views have lengths %d and %d, expected %d", m.Atomic().Len(), m.Bitset().Len(), m.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		b := New(m.Len())
+		rand.Read(b)
+
+		copy(m.Bitset(), b)
+
+		bit := uint(rand.Intn(int(m.Len())))
+		m.Atomic().Invert(bit)
+		b.Invert(bit)
+
+		if err := m.Sync(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !m.Bitset().Equal(b) {
+			t.Fatal("Atomic view does not match Bitset view")
+		}
+
+		if data := readMapFile(t, f); !bytes.Equal(data, b) {
+			t.Fatalf("file contains %x, expected %x", data, []byte(b))
+		}
+	}
+
+	if err := m.Flush(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMapFileExisting(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	b := New(200)
+	rand.Read(b)
+
+	if _, err := f.Write(b); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := MapFile(f, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if m.Len() != 256 {
+		t.Fatalf("Len returned %d, expected 256", m.Len())
+	}
+
+	if !bytes.Equal(m.Bitset()[:len(b)], b) || !m.Bitset().IsRangeClear(b.Len(), m.Len()) {
+		t.Fatalf("mapping contains %x, expected %x", []byte(m.Bitset()), []byte(b))
+	}
+}
+
+func TestMapFileGrow(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	m, err := MapFile(f, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if m.Len() != 0 || m.Bitset() != nil || m.Atomic() != nil {
+		t.Fatalf("empty mapping has length %d", m.Len())
+	}
+
+	b := New(0)
+	for _, size := range []uint{1, 64, 65, 1000, 64 << 10, 1 << 20, 1000} {
+		if err := m.Grow(size); err != nil {
+			t.Fatal(err)
+		}
+
+		if size > b.Len() {
+			b1 := New((size + 63) &^ 63)
+			copy(b1, b)
+			b = b1
+		}
+
+		if m.Len() != b.Len() {
+			t.Fatalf("Len returned %d after Grow(%d), expected %d", m.Len(), size, b.Len())
+		}
+
+		if !m.Bitset().Equal(b) {
+			t.Fatalf("Grow(%d) did not preserve contents", size)
+		}
+
+		bit := uint(rand.Intn(int(b.Len())))
+		m.Atomic().Set(bit)
+		b.Set(bit)
+	}
+
+	if err := m.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if data := readMapFile(t, f); !bytes.Equal(data, b) {
+		t.Fatal("file does not match mapping after Grow")
+	}
+}
+
+func TestMapFileGrowTooLarge(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	m, err := MapFile(f, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	m.Bitset().Set(100)
+
+	for _, size := range []uint{^uint(0), ^uint(0) - 10, ^uint(0)&^63 + 1} {
+		if err := m.Grow(size); err != errMappingTooLarge {
+			t.Errorf("Grow(%d) returned %v, expected %v", size, err, errMappingTooLarge)
+		}
+
+		if m.Len() != 128 || !m.Bitset().IsSet(100) {
+			t.Fatalf("Grow(%d) replaced the mapping, Len is %d", size, m.Len())
+		}
+	}
+}
+
+func TestMapFileReadOnly(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	b := New(100)
+	rand.Read(b)
+
+	if _, err := f.Write(b); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := MapFileReadOnly(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if !m.ReadOnly() {
+		t.Error("ReadOnly returned false")
+	}
+
+	if !m.Bitset().Equal(b) {
+		t.Fatalf("mapping contains %x, expected %x", []byte(m.Bitset()), []byte(b))
+	}
+
+	if a := m.Atomic(); a.Len() != 64 || a.IsSet(3) != b.IsSet(3) {
+		t.Errorf("Atomic view has length %d, expected 64", a.Len())
+	}
+
+	if err := m.Grow(1000); err != errMappingReadOnly {
+		t.Errorf("Grow returned %v, expected %v", err, errMappingReadOnly)
+	}
+
+	if err := m.Sync(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMapFileShared(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	m1, err := MapFile(f, 1<<16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m1.Close()
+
+	m2, err := MapFile(f, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m2.Close()
+
+	m1.Atomic().Set(12345)
+
+	if !m2.Atomic().IsSet(12345) {
+		t.Error("write through one mapping not seen by the other")
+	}
+}
+
+func TestMapFileClose(t *testing.T) {
+	f := tempMapFile(t)
+	defer f.Close()
+
+	m, err := MapFile(f, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Bitset().Set(9)
+
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fn := range map[string]func() error{
+		"Close": m.Close,
+		"Sync":  m.Sync,
+		"Flush": m.Flush,
+		"Grow":  func() error { return m.Grow(1000) },
+	} {
+		if err := fn(); err != errMappingClosed {
+			t.Errorf("%s returned %v, expected %v", name, err, errMappingClosed)
+		}
+	}
+
+	if data := readMapFile(t, f); !Bitset(data).IsSet(9) {
+		t.Error("changes lost on Close")
+	}
+}
+
+func BenchmarkMappingAtomicSet(b *testing.B) {
+	f := tempMapFile(b)
+	defer f.Close()
+
+	m, err := MapFile(f, 1<<20)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer m.Close()
+
+	a := m.Atomic()
+
+	for i := 0; i < b.N; i++ {
+		a.Set(uint(i) & (1<<20 - 1))
+	}
+}
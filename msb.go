@@ -0,0 +1,153 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/tmthrgd/go-hex"
+)
+
+// MSBBitset is a Bitset that stores bits MSB-first within
+// each byte, so bit i is b[i>>3]&(0x80>>(i&7)). This is the
+// order used by most network protocols and file formats.
+//
+// Other than the layout of bits within each byte, it
+// behaves exactly as Bitset does.
+type MSBBitset []byte
+
+func NewMSB(size uint) MSBBitset {
+	return MSBBitset(New(size))
+}
+
+func (b MSBBitset) Len() uint {
+	return uint(len(b)) << 3
+}
+
+func (b MSBBitset) ByteLen() int {
+	return len(b)
+}
+
+func (b MSBBitset) Clone() MSBBitset {
+	return append(MSBBitset(nil), b...)
+}
+
+func (b MSBBitset) String() string {
+	const maxSize = 128
+
+	if len(b) > maxSize {
+		return "MSBBitset{" + hex.EncodeToString(b[:maxSize]) + "...}"
+	}
+
+	return "MSBBitset{" + hex.EncodeToString(b) + "}"
+}
+
+// reverseBits reverses the order of the bits within each
+// byte of b.
+func reverseBits(b []byte) {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		w := binary.LittleEndian.Uint64(b[i:])
+		binary.LittleEndian.PutUint64(b[i:], bits.ReverseBytes64(bits.Reverse64(w)))
+	}
+
+	for ; i < len(b); i++ {
+		b[i] = bits.Reverse8(b[i])
+	}
+}
+
+// ToMSB converts b, in place, to the MSB-first layout and
+// returns it as an MSBBitset. It shares b's storage, so b
+// must not be used as a Bitset afterwards.
+func (b Bitset) ToMSB() MSBBitset {
+	reverseBits(b)
+	return MSBBitset(b)
+}
+
+// ToLSB converts b, in place, to the LSB-first layout and
+// returns it as a Bitset. It shares b's storage, so b must
+// not be used as an MSBBitset afterwards.
+func (b MSBBitset) ToLSB() Bitset {
+	reverseBits(b)
+	return Bitset(b)
+}
+
+// MSB returns a copy of b in the MSB-first layout.
+func (b Bitset) MSB() MSBBitset {
+	return b.Clone().ToMSB()
+}
+
+// LSB returns a copy of b in the LSB-first layout.
+func (b MSBBitset) LSB() Bitset {
+	return b.Clone().ToLSB()
+}
+
+func msbMask1(start, end uint) byte {
+	return bits.Reverse8(mask1(start, end))
+}
+
+func msbMask2(start, end uint) byte {
+	return bits.Reverse8(mask2(start, end))
+}
+
+func (b MSBBitset) Equal(b1 MSBBitset) bool {
+	return Bitset(b).Equal(Bitset(b1))
+}
+
+func (b MSBBitset) Count() uint {
+	return Bitset(b).Count()
+}
+
+func (b MSBBitset) CountRange(start, end uint) uint {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	var x uint16
+
+	if mask := msbMask1(start, end); mask != 0 {
+		x = uint16(b[start>>3] & mask)
+	}
+
+	var total uint
+	if start := (start + 7) &^ 7; start < end {
+		total = Bitset(b[start>>3 : end>>3]).Count()
+	}
+
+	if mask := msbMask2(start, end); mask != 0 {
+		x |= uint16(b[end>>3]&mask) << 8
+	}
+
+	return uint(bits.OnesCount16(x)) + total
+}
+
+// The bitwise operations do not depend on the layout of
+// bits within each byte.
+
+func (b MSBBitset) Complement(b1 MSBBitset) {
+	Bitset(b).Complement(Bitset(b1))
+}
+
+func (b MSBBitset) Union(b1, b2 MSBBitset) {
+	Bitset(b).Union(Bitset(b1), Bitset(b2))
+}
+
+func (b MSBBitset) Intersection(b1, b2 MSBBitset) {
+	Bitset(b).Intersection(Bitset(b1), Bitset(b2))
+}
+
+func (b MSBBitset) Difference(b1, b2 MSBBitset) {
+	Bitset(b).Difference(Bitset(b1), Bitset(b2))
+}
+
+func (b MSBBitset) SymmetricDifference(b1, b2 MSBBitset) {
+	Bitset(b).SymmetricDifference(Bitset(b1), Bitset(b2))
+}
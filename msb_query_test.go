@@ -0,0 +1,57 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestMSBIsRange(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(b Bitset, start, end uint) bool
+		msb  func(b MSBBitset, start, end uint) bool
+	}{
+		{"IsRangeSet", Bitset.IsRangeSet, MSBBitset.IsRangeSet},
+		{"IsRangeClear", Bitset.IsRangeClear, MSBBitset.IsRangeClear},
+	} {
+		if err := quick.CheckEqual(func(b, b1 Bitset, start, end uint) bool {
+			b = b.Clone()
+			b.SetRangeTo(start, end, b1.IsSet(start))
+			return v.fn(b, start, end)
+		}, func(b, b1 Bitset, start, end uint) bool {
+			m := b.MSB()
+			m.SetRangeTo(start, end, b1.IsSet(start))
+			return v.msb(m, start, end)
+		}, &quick.Config{
+			Values:        rangeTestValues2,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestMSBAll(t *testing.T) {
+	m := NewMSB(80)
+
+	if !m.None() || m.Any() || m.All() {
+		t.Error("None failed")
+	}
+
+	m.Set(79)
+
+	if m.None() || !m.Any() || m.All() {
+		t.Error("Any failed")
+	}
+
+	m.SetAll()
+
+	if !m.All() {
+		t.Error("All failed")
+	}
+}
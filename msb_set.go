@@ -0,0 +1,134 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "github.com/tmthrgd/go-memset"
+
+func (b MSBBitset) Set(bit uint) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	b[bit>>3] |= 0x80 >> (bit & 7)
+}
+
+func (b MSBBitset) Clear(bit uint) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	b[bit>>3] &^= 0x80 >> (bit & 7)
+}
+
+func (b MSBBitset) Invert(bit uint) {
+	if bit > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	b[bit>>3] ^= 0x80 >> (bit & 7)
+}
+
+func (b MSBBitset) SetRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := msbMask1(start, end); mask != 0 {
+		b[start>>3] |= mask
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		memset.Memset(b[start>>3:end>>3], 0xff)
+	}
+
+	if mask := msbMask2(start, end); mask != 0 {
+		b[end>>3] |= mask
+	}
+}
+
+func (b MSBBitset) ClearRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := msbMask1(start, end); mask != 0 {
+		b[start>>3] &^= mask
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		memset.Memset(b[start>>3:end>>3], 0)
+	}
+
+	if mask := msbMask2(start, end); mask != 0 {
+		b[end>>3] &^= mask
+	}
+}
+
+func (b MSBBitset) InvertRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	if mask := msbMask1(start, end); mask != 0 {
+		b[start>>3] ^= mask
+	}
+
+	if start := (start + 7) &^ 7; start < end {
+		Bitset(b[start>>3 : end>>3]).InvertAll()
+	}
+
+	if mask := msbMask2(start, end); mask != 0 {
+		b[end>>3] ^= mask
+	}
+}
+
+func (b MSBBitset) SetTo(bit uint, value bool) {
+	if value {
+		b.Set(bit)
+	} else {
+		b.Clear(bit)
+	}
+}
+
+func (b MSBBitset) SetRangeTo(start, end uint, value bool) {
+	if value {
+		b.SetRange(start, end)
+	} else {
+		b.ClearRange(start, end)
+	}
+}
+
+func (b MSBBitset) SetAll() {
+	memset.Memset(b, 0xff)
+}
+
+func (b MSBBitset) ClearAll() {
+	memset.Memset(b, 0)
+}
+
+func (b MSBBitset) InvertAll() {
+	Bitset(b).InvertAll()
+}
+
+func (b MSBBitset) SetAllTo(value bool) {
+	if value {
+		b.SetAll()
+	} else {
+		b.ClearAll()
+	}
+}
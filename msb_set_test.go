@@ -0,0 +1,56 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestMSBSet(t *testing.T) {
+	m := NewMSB(16)
+
+	m.Set(0)
+	m.Set(15)
+
+	if m[0] != 0x80 || m[1] != 0x01 {
+		t.Errorf("Set failed, got %s", m)
+	}
+
+	m.Invert(1)
+	m.Clear(0)
+
+	if m[0] != 0x40 || !m.IsSet(1) || m.IsSet(0) {
+		t.Errorf("Invert or Clear failed, got %s", m)
+	}
+}
+
+func TestMSBSetRange(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(b Bitset, start, end uint)
+		msb  func(b MSBBitset, start, end uint)
+	}{
+		{"SetRange", Bitset.SetRange, MSBBitset.SetRange},
+		{"ClearRange", Bitset.ClearRange, MSBBitset.ClearRange},
+		{"InvertRange", Bitset.InvertRange, MSBBitset.InvertRange},
+	} {
+		if err := quick.CheckEqual(func(b, _ Bitset, start, end uint) []byte {
+			b = b.Clone()
+			v.fn(b, start, end)
+			return b
+		}, func(b, _ Bitset, start, end uint) []byte {
+			m := b.MSB()
+			v.msb(m, start, end)
+			return m.LSB()
+		}, &quick.Config{
+			Values:        rangeTestValues2,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
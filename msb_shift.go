@@ -0,0 +1,156 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "encoding/binary"
+
+// ShiftLeft and ShiftRight have the same meaning as they
+// do for Bitset. With the MSB-first layout, b is a
+// big-endian stream of bits, so ShiftLeft moves bits
+// towards the start of b.
+
+func (b MSBBitset) ShiftLeft(b1 MSBBitset, shift uint) {
+	if shift > b1.Len() {
+		panic(errOutOfRange)
+	}
+
+	switch {
+	case !useShiftFastPath:
+		// slow path
+		l := b1.Len() - shift
+		if b.Len() < l {
+			l = b.Len()
+		}
+
+		for i := uint(0); i < l; i++ {
+			b.SetTo(i, b1.IsSet(i+shift))
+		}
+	case shift&7 == 0:
+		// fast path
+		copy(b, b1[shift>>3:])
+	default:
+		msbShiftLeftWords(b, b1, shift)
+	}
+}
+
+func (b MSBBitset) ShiftRight(b1 MSBBitset, shift uint) {
+	if shift > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	switch {
+	case !useShiftFastPath:
+		// slow path
+		l := b.Len()
+		if b1.Len() < l-shift {
+			l = b1.Len() + shift
+		}
+
+		for i := l; i > shift; i-- {
+			b.SetTo(i-1, b1.IsSet(i-1-shift))
+		}
+	case shift&7 == 0:
+		// fast path
+		copy(b[shift>>3:], b1)
+	default:
+		msbShiftRightWords(b, b1, shift)
+	}
+}
+
+// msbShiftLeftWords is shiftLeftWords for MSBBitset. It
+// works forwards through b so that b and b1 may be the
+// same MSBBitset.
+func msbShiftLeftWords(b, b1 MSBBitset, shift uint) {
+	l := b1.Len() - shift
+	if b.Len() < l {
+		l = b.Len()
+	}
+
+	q, r := int(shift>>3), shift&7
+	n := int(l >> 3)
+
+	j := 0
+	for ; j+8 <= n && j+q+9 <= len(b1); j += 8 {
+		w := binary.BigEndian.Uint64(b1[j+q:])<<r | uint64(b1[j+q+8])>>(8-r)
+		binary.BigEndian.PutUint64(b[j:], w)
+	}
+
+	for ; j < n; j++ {
+		b[j] = msbShiftLeftByte(b1, j+q, r)
+	}
+
+	if l&7 != 0 {
+		mask := ^byte(0) << (8 - l&7)
+		b[j] = b[j]&^mask | msbShiftLeftByte(b1, j+q, r)&mask
+	}
+}
+
+func msbShiftLeftByte(b1 MSBBitset, i int, r uint) byte {
+	x := b1[i] << r
+	if i+1 < len(b1) {
+		x |= b1[i+1] >> (8 - r)
+	}
+
+	return x
+}
+
+// msbShiftRightWords is shiftRightWords for MSBBitset. It
+// works backwards through b so that b and b1 may be the
+// same MSBBitset.
+func msbShiftRightWords(b, b1 MSBBitset, shift uint) {
+	l := b.Len()
+	if b1.Len() < l-shift {
+		l = b1.Len() + shift
+	}
+
+	if l <= shift {
+		return
+	}
+
+	q, r := int(shift>>3), shift&7
+	first, last := q, int((l-1)>>3)
+
+	mask := ^byte(0)
+	if l&7 != 0 {
+		mask = ^byte(0) << (8 - l&7)
+	}
+
+	if first == last {
+		mask &= ^byte(0) >> r
+	}
+
+	b[last] = b[last]&^mask | msbShiftRightByte(b1, last-q, r)&mask
+
+	if first == last {
+		return
+	}
+
+	j := last
+	for ; j-8 > first && j-q <= len(b1); j -= 8 {
+		w := binary.BigEndian.Uint64(b1[j-q-8:])>>r | uint64(b1[j-q-9])<<(64-r)
+		binary.BigEndian.PutUint64(b[j-8:], w)
+	}
+
+	for j--; j > first; j-- {
+		b[j] = msbShiftRightByte(b1, j-q, r)
+	}
+
+	mask = ^byte(0) >> r
+	b[first] = b[first]&^mask | msbShiftRightByte(b1, 0, r)&mask
+}
+
+func msbShiftRightByte(b1 MSBBitset, i int, r uint) byte {
+	var x byte
+	if i < len(b1) {
+		x = b1[i] >> r
+	}
+
+	if i > 0 {
+		x |= b1[i-1] << (8 - r)
+	}
+
+	return x
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestMSBShiftLeft(t *testing.T) {
+	for _, slow := range []bool{false, true} {
+		if err := quick.CheckEqual(func(b, b1 Bitset, shift uint) []byte {
+			b = b.Clone()
+
+			if shift <= b1.Len() {
+				b.ShiftLeft(b1, shift)
+			}
+
+			return b
+		}, func(b, b1 Bitset, shift uint) []byte {
+			m := b.MSB()
+
+			fn := func() {
+				if shift <= b1.Len() {
+					m.ShiftLeft(b1.MSB(), shift)
+				}
+			}
+
+			if slow {
+				testShiftSlowPath(fn)
+			} else {
+				fn()
+			}
+
+			return m.LSB()
+		}, &quick.Config{
+			Values:        rangeTestShiftValues2,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("ShiftLeft failed (slow path %t): %v", slow, err)
+		}
+	}
+}
+
+func TestMSBShiftRight(t *testing.T) {
+	for _, slow := range []bool{false, true} {
+		if err := quick.CheckEqual(func(b, b1 Bitset, shift uint) []byte {
+			b = b.Clone()
+			b.ShiftRight(b1, shift)
+			return b
+		}, func(b, b1 Bitset, shift uint) []byte {
+			m := b.MSB()
+
+			fn := func() {
+				m.ShiftRight(b1.MSB(), shift)
+			}
+
+			if slow {
+				testShiftSlowPath(fn)
+			} else {
+				fn()
+			}
+
+			return m.LSB()
+		}, &quick.Config{
+			Values:        rangeTestShiftValues2,
+			MaxCountScale: 100,
+		}); err != nil {
+			t.Errorf("ShiftRight failed (slow path %t): %v", slow, err)
+		}
+	}
+}
+
+func TestMSBShiftInPlace(t *testing.T) {
+	if err := quick.Check(func(b Bitset, shift uint) bool {
+		shift %= b.Len() + 1
+
+		b1 := b.Clone()
+		b1.ShiftLeft(b1, shift)
+
+		m := b.MSB()
+		m.ShiftLeft(m, shift)
+
+		b2 := b.Clone()
+		b2.ShiftRight(b2, shift)
+
+		m1 := b.MSB()
+		m1.ShiftRight(m1, shift)
+
+		return m.LSB().Equal(b1) && m1.LSB().Equal(b2)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestMSBConvert(t *testing.T) {
+	b := New(24)
+	b.Set(0)
+	b.Set(9)
+	b.Set(23)
+
+	m := b.MSB()
+
+	if exp := (MSBBitset{0x80, 0x40, 0x01}); !m.Equal(exp) {
+		t.Errorf("MSB failed, expected %s, got %s", exp, m)
+	}
+
+	if !m.LSB().Equal(b) {
+		t.Errorf("LSB failed, expected %s, got %s", b, m.LSB())
+	}
+
+	if err := quick.Check(func(b Bitset) bool {
+		m := b.MSB()
+
+		for i := uint(0); i < b.Len(); i++ {
+			if m.IsSet(i) != b.IsSet(i) {
+				return false
+			}
+		}
+
+		return m.LSB().Equal(b) && b.Clone().ToMSB().ToLSB().Equal(b)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMSBCount(t *testing.T) {
+	if err := quick.CheckEqual(func(b, _ Bitset, start, end uint) uint {
+		return b.CountRange(start, end)
+	}, func(b, _ Bitset, start, end uint) uint {
+		return b.MSB().CountRange(start, end)
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMSBBitwise(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(b, b1, b2 Bitset)
+		msb  func(b, b1, b2 MSBBitset)
+	}{
+		{"Union", Bitset.Union, MSBBitset.Union},
+		{"Intersection", Bitset.Intersection, MSBBitset.Intersection},
+		{"Difference", Bitset.Difference, MSBBitset.Difference},
+		{"SymmetricDifference", Bitset.SymmetricDifference, MSBBitset.SymmetricDifference},
+	} {
+		if err := quick.CheckEqual(func(b, b1 Bitset, _, _ uint) []byte {
+			b2 := New(b.Len())
+			v.fn(b2, b, b1)
+			return b2
+		}, func(b, b1 Bitset, _, _ uint) []byte {
+			b2 := NewMSB(b.Len())
+			v.msb(b2, b.MSB(), b1.MSB())
+			return b2.LSB()
+		}, &quick.Config{
+			Values:        rangeTestValues2,
+			MaxCountScale: 10,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sort"
+
+	"github.com/tmthrgd/go-popcount"
+)
+
+const (
+	rankBlockBits  = 512
+	rankBlockBytes = rankBlockBits >> 3
+
+	selectSampleRate = 4096
+)
+
+// RankSelect is a succinct rank and select index over
+// a Bitset.
+//
+// The index is not updated when the underlying Bitset
+// is modified, Rebuild must be called after any
+// mutation.
+type RankSelect struct {
+	b Bitset
+
+	// blocks[j] holds the number of set bits before
+	// block j, blocks[len(blocks)-1] holds b.Count().
+	blocks []uint64
+
+	// ones[s] and zeros[s] hold the block containing
+	// the s*selectSampleRate'th set and clear bit.
+	ones, zeros []int
+}
+
+func NewRankSelect(b Bitset) *RankSelect {
+	rs := &RankSelect{b: b}
+	rs.Rebuild()
+	return rs
+}
+
+func (rs *RankSelect) Bitset() Bitset {
+	return rs.b
+}
+
+func (rs *RankSelect) Rebuild() {
+	n := (len(rs.b) + rankBlockBytes - 1) / rankBlockBytes
+
+	if cap(rs.blocks) > n {
+		rs.blocks = rs.blocks[:n+1]
+	} else {
+		rs.blocks = make([]uint64, n+1)
+	}
+
+	var total uint64
+	for j := 0; j < n; j++ {
+		rs.blocks[j] = total
+		total += popcount.CountBytes(rs.block(j))
+	}
+
+	rs.blocks[n] = total
+
+	rs.sample()
+}
+
+func (rs *RankSelect) sample() {
+	rs.ones, rs.zeros = rs.ones[:0], rs.zeros[:0]
+
+	for j := 0; j < len(rs.blocks)-1; j++ {
+		for uint64(len(rs.ones))*selectSampleRate < rs.blocks[j+1] {
+			rs.ones = append(rs.ones, j)
+		}
+
+		for uint64(len(rs.zeros))*selectSampleRate < rs.zerosBefore(j+1) {
+			rs.zeros = append(rs.zeros, j)
+		}
+	}
+}
+
+func (rs *RankSelect) block(j int) Bitset {
+	start, end := j*rankBlockBytes, (j+1)*rankBlockBytes
+	if end > len(rs.b) {
+		end = len(rs.b)
+	}
+
+	return rs.b[start:end]
+}
+
+func (rs *RankSelect) zerosBefore(j int) uint64 {
+	n := uint64(j) * rankBlockBits
+	if l := uint64(rs.b.Len()); n > l {
+		n = l
+	}
+
+	return n - rs.blocks[j]
+}
+
+func (rs *RankSelect) Count() uint {
+	return uint(rs.blocks[len(rs.blocks)-1])
+}
+
+// Rank1 returns the number of set bits before bit. It
+// is equivalent to CountRange(0, bit).
+func (rs *RankSelect) Rank1(bit uint) uint {
+	if bit > rs.b.Len() {
+		panic(errOutOfRange)
+	}
+
+	j := bit / rankBlockBits
+	rank := rs.blocks[j] + popcount.CountBytes(rs.b[j*rankBlockBytes:bit>>3])
+
+	if bit&7 != 0 {
+		rank += uint64(bits.OnesCount8(rs.b[bit>>3] & (1<<(bit&7) - 1)))
+	}
+
+	return uint(rank)
+}
+
+// Rank0 returns the number of clear bits before bit.
+func (rs *RankSelect) Rank0(bit uint) uint {
+	return bit - rs.Rank1(bit)
+}
+
+// Select1 returns the index of the k'th set bit,
+// counting from zero.
+func (rs *RankSelect) Select1(k uint) (uint, bool) {
+	if uint64(k) >= rs.blocks[len(rs.blocks)-1] {
+		return 0, false
+	}
+
+	j := rs.search(uint64(k), rs.ones, func(j int) uint64 {
+		return rs.blocks[j]
+	})
+	return rs.selectBlock(j, uint64(k)-rs.blocks[j], 0), true
+}
+
+// Select0 returns the index of the k'th clear bit,
+// counting from zero.
+func (rs *RankSelect) Select0(k uint) (uint, bool) {
+	if uint64(k) >= rs.zerosBefore(len(rs.blocks)-1) {
+		return 0, false
+	}
+
+	j := rs.search(uint64(k), rs.zeros, rs.zerosBefore)
+	return rs.selectBlock(j, uint64(k)-rs.zerosBefore(j), 0xff), true
+}
+
+func (rs *RankSelect) search(k uint64, samples []int, before func(j int) uint64) int {
+	lo, hi := 0, len(rs.blocks)-1
+
+	if s := k / selectSampleRate; s < uint64(len(samples)) {
+		lo = samples[s]
+
+		if s+1 < uint64(len(samples)) {
+			hi = samples[s+1] + 1
+		}
+	}
+
+	return lo + sort.Search(hi-lo, func(n int) bool {
+		return before(lo+n+1) > k
+	})
+}
+
+func (rs *RankSelect) selectBlock(j int, k uint64, invert byte) uint {
+	block := rs.block(j)
+	invert64 := uint64(invert) * 0x0101010101010101
+
+	var i int
+	for ; i+8 <= len(block); i += 8 {
+		w := binary.LittleEndian.Uint64(block[i:]) ^ invert64
+
+		if c := uint64(bits.OnesCount64(w)); k >= c {
+			k -= c
+			continue
+		}
+
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+
+		return uint(j*rankBlockBits+i<<3) + uint(bits.TrailingZeros64(w))
+	}
+
+	for ; ; i++ {
+		w := block[i] ^ invert
+
+		if c := uint64(bits.OnesCount8(w)); k >= c {
+			k -= c
+			continue
+		}
+
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+
+		return uint(j*rankBlockBits+i<<3) + uint(bits.TrailingZeros8(w))
+	}
+}
+
+// MarshalBinary encodes the underlying Bitset in the
+// same format as Bitset.MarshalBinary. The rank
+// directory is not encoded, it is rebuilt by
+// UnmarshalBinary.
+func (rs *RankSelect) MarshalBinary() ([]byte, error) {
+	return rs.b.MarshalBinary()
+}
+
+// UnmarshalBinary decodes an encoded Bitset and builds
+// the rank directory over it.
+func (rs *RankSelect) UnmarshalBinary(data []byte) error {
+	var b Bitset
+	if err := b.UnmarshalBinary(data); err != nil {
+		return err
+	}
+
+	*rs = *NewRankSelect(b)
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func rankSelectTestValues(args []reflect.Value, rand *rand.Rand) {
+	size := 1 + rand.Intn(1<<16)
+
+	b := New(uint(size))
+	switch rand.Intn(3) {
+	case 0:
+		rand.Read(b)
+	case 1:
+		b.SetAll()
+		fallthrough
+	case 2:
+		for n := rand.Intn(64); n > 0; n-- {
+			b.Invert(uint(rand.Intn(size)))
+		}
+	}
+
+	args[0] = reflect.ValueOf(b)
+}
+
+func TestRankSelect(t *testing.T) {
+	b := New(2000)
+	b.Set(3)
+	b.Set(600)
+	b.SetRange(1500, 1600)
+
+	rs := NewRankSelect(b)
+
+	if c := rs.Count(); c != 102 {
+		t.Errorf("Count failed, expected 102, got %d", c)
+	}
+
+	for _, v := range []struct {
+		bit, rank uint
+	}{
+		{0, 0}, {3, 0}, {4, 1}, {600, 1}, {601, 2},
+		{1550, 52}, {b.Len(), 102},
+	} {
+		if rank := rs.Rank1(v.bit); rank != v.rank {
+			t.Errorf("Rank1(%d) failed, expected %d, got %d", v.bit, v.rank, rank)
+		}
+	}
+
+	for _, v := range []struct {
+		k, bit uint
+		ok     bool
+	}{
+		{0, 3, true}, {1, 600, true}, {2, 1500, true},
+		{101, 1599, true}, {102, 0, false},
+	} {
+		if bit, ok := rs.Select1(v.k); bit != v.bit || ok != v.ok {
+			t.Errorf("Select1(%d) failed, expected (%d, %t), got (%d, %t)", v.k, v.bit, v.ok, bit, ok)
+		}
+	}
+
+	if bit, ok := rs.Select0(3); bit != 4 || !ok {
+		t.Errorf("Select0(3) failed, expected (4, true), got (%d, %t)", bit, ok)
+	}
+}
+
+func TestRankSelectRank(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		rs := NewRankSelect(b)
+
+		for i := uint(0); i <= b.Len(); i += 1 + uint(rand.Intn(64)) {
+			if rs.Rank1(i) != b.CountRange(0, i) ||
+				rs.Rank0(i) != i-b.CountRange(0, i) {
+				return false
+			}
+		}
+
+		return rs.Rank1(b.Len()) == b.Count()
+	}, &quick.Config{
+		Values: rankSelectTestValues,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRankSelectSelect(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		rs := NewRankSelect(b)
+
+		var ones, zeros uint
+		for i := uint(0); i < b.Len(); i++ {
+			bit, ok := rs.Select0(zeros)
+			if b.IsSet(i) {
+				bit, ok = rs.Select1(ones)
+				ones++
+			} else {
+				zeros++
+			}
+
+			if bit != i || !ok {
+				return false
+			}
+		}
+
+		_, ok1 := rs.Select1(ones)
+		_, ok0 := rs.Select0(zeros)
+		return !ok1 && !ok0
+	}, &quick.Config{
+		Values: rankSelectTestValues,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRankSelectRebuild(t *testing.T) {
+	b := New(1000)
+	rs := NewRankSelect(b)
+
+	b.SetRange(100, 200)
+	rs.Rebuild()
+
+	if rank := rs.Rank1(150); rank != 50 {
+		t.Errorf("Rank1 failed after Rebuild, expected 50, got %d", rank)
+	}
+
+	if bit, ok := rs.Select1(0); bit != 100 || !ok {
+		t.Errorf("Select1 failed after Rebuild, expected (100, true), got (%d, %t)", bit, ok)
+	}
+}
+
+func TestRankSelectMarshalBinary(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		rs := NewRankSelect(b)
+
+		data, err := rs.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		var rs1 RankSelect
+		if err := rs1.UnmarshalBinary(data); err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(rs, &rs1)
+	}, &quick.Config{
+		Values:        rankSelectTestValues,
+		MaxCountScale: 0.1,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	b := New(2000)
+	b.SetRange(100, 1500)
+
+	data, _ := NewRankSelect(b).MarshalBinary()
+
+	if data1, _ := b.MarshalBinary(); !bytes.Equal(data, data1) {
+		t.Error("MarshalBinary did not use the Bitset encoding")
+	}
+
+	var rs RankSelect
+	if err := rs.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if rs.Count() != 1400 || rs.Rank1(2000) != 1400 {
+		t.Errorf("UnmarshalBinary failed to rebuild directory, got Count of %d", rs.Count())
+	}
+
+	data[len(data)-1] ^= 0xff
+
+	if err := new(RankSelect).UnmarshalBinary(data); err != errBinaryChecksum {
+		t.Errorf("UnmarshalBinary failed, expected %v, got %v", errBinaryChecksum, err)
+	}
+
+	if err := new(RankSelect).UnmarshalBinary(data[:10]); err != errBinaryInvalid {
+		t.Errorf("UnmarshalBinary failed, expected %v, got %v", errBinaryInvalid, err)
+	}
+}
+
+func BenchmarkRankSelectRank1(b *testing.B) {
+	bs := New(1 << 24)
+	rand.Read(bs)
+	rs := NewRankSelect(bs)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var _ = rs.Rank1(uint(i) & (1<<24 - 1))
+	}
+}
+
+func BenchmarkRankSelectSelect1(b *testing.B) {
+	bs := New(1 << 24)
+	rand.Read(bs)
+	rs := NewRankSelect(bs)
+	c := rs.Count()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var _, _ = rs.Select1(uint(i) % c)
+	}
+}
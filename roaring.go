@@ -0,0 +1,279 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+const roaringMax = 1<<32 - 1
+
+// Roaring is a compressed bitmap of up to 1<<32 bits.
+//
+// Each 64K chunk of bits is held in whichever of a
+// sorted array, a dense Bitset or a list of runs is
+// smallest. The zero value is an empty bitmap.
+type Roaring struct {
+	keys       []uint16
+	containers []container
+}
+
+func NewRoaring() *Roaring {
+	return new(Roaring)
+}
+
+func NewRoaringFromBitset(b Bitset) *Roaring {
+	if uint64(b.Len()) > roaringMax+1 {
+		panic(errOutOfRange)
+	}
+
+	r := new(Roaring)
+
+	for i := 0; i < len(b); i += containerBytes {
+		chunk := b[i:]
+		if len(chunk) > containerBytes {
+			chunk = chunk[:containerBytes]
+		}
+
+		n := int(chunk.Count())
+		if n == 0 {
+			continue
+		}
+
+		b1 := make(Bitset, containerBytes)
+		copy(b1, chunk)
+
+		r.keys = append(r.keys, uint16(i/containerBytes))
+		r.containers = append(r.containers, newContainer(b1, n))
+	}
+
+	return r
+}
+
+// Bitset returns a dense copy of r. It is just long
+// enough to hold the highest set bit. On platforms
+// where that length does not fit in a uint, such as
+// when bit 1<<32-1 is set on 32-bit platforms, it
+// panics.
+func (r *Roaring) Bitset() Bitset {
+	if len(r.keys) == 0 {
+		return New(0)
+	}
+
+	last := len(r.keys) - 1
+	max, _ := r.containers[last].bitmap().PrevSet(containerBits)
+
+	n := uint64(r.keys[last])<<16 + uint64(max) + 1
+	if n > uint64(^uint(0)-7) {
+		panic(errOutOfRange)
+	}
+
+	b := New(uint(n))
+	for i, key := range r.keys {
+		copy(b[int(key)*containerBytes:], r.containers[i].bitmap())
+	}
+
+	return b
+}
+
+func (r *Roaring) search(key uint16) int {
+	return sort.Search(len(r.keys), func(i int) bool {
+		return r.keys[i] >= key
+	})
+}
+
+func (r *Roaring) IsSet(bit uint) bool {
+	if bit > roaringMax {
+		panic(errOutOfRange)
+	}
+
+	key := uint16(bit >> 16)
+
+	i := r.search(key)
+	return i < len(r.keys) && r.keys[i] == key &&
+		r.containers[i].contains(uint16(bit))
+}
+
+func (r *Roaring) IsClear(bit uint) bool {
+	return !r.IsSet(bit)
+}
+
+func (r *Roaring) Set(bit uint) {
+	if bit > roaringMax {
+		panic(errOutOfRange)
+	}
+
+	key := uint16(bit >> 16)
+
+	i := r.search(key)
+	if i < len(r.keys) && r.keys[i] == key {
+		r.containers[i] = r.containers[i].add(uint16(bit))
+		return
+	}
+
+	r.keys = append(r.keys, 0)
+	copy(r.keys[i+1:], r.keys[i:])
+	r.keys[i] = key
+
+	r.containers = append(r.containers, nil)
+	copy(r.containers[i+1:], r.containers[i:])
+	r.containers[i] = arrayContainer{uint16(bit)}
+}
+
+func (r *Roaring) Clear(bit uint) {
+	if bit > roaringMax {
+		panic(errOutOfRange)
+	}
+
+	key := uint16(bit >> 16)
+
+	i := r.search(key)
+	if i == len(r.keys) || r.keys[i] != key {
+		return
+	}
+
+	if c := r.containers[i].remove(uint16(bit)); c.count() != 0 {
+		r.containers[i] = c
+		return
+	}
+
+	r.keys = append(r.keys[:i], r.keys[i+1:]...)
+	r.containers = append(r.containers[:i], r.containers[i+1:]...)
+}
+
+func (r *Roaring) SetTo(bit uint, value bool) {
+	if value {
+		r.Set(bit)
+	} else {
+		r.Clear(bit)
+	}
+}
+
+func (r *Roaring) Count() uint {
+	var n uint
+	for _, c := range r.containers {
+		n += uint(c.count())
+	}
+
+	return n
+}
+
+func (r *Roaring) Any() bool {
+	return len(r.keys) != 0
+}
+
+func (r *Roaring) None() bool {
+	return len(r.keys) == 0
+}
+
+func (r *Roaring) Clone() *Roaring {
+	r1 := &Roaring{
+		keys:       append([]uint16(nil), r.keys...),
+		containers: make([]container, len(r.containers)),
+	}
+
+	for i, c := range r.containers {
+		r1.containers[i] = c.clone()
+	}
+
+	return r1
+}
+
+func (r *Roaring) Equal(r1 *Roaring) bool {
+	if len(r.keys) != len(r1.keys) {
+		return false
+	}
+
+	for i, key := range r.keys {
+		c, c1 := r.containers[i], r1.containers[i]
+
+		if key != r1.keys[i] || c.count() != c1.count() {
+			return false
+		}
+
+		if !bytes.Equal(c.bitmap(), c1.bitmap()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Optimize converts each chunk of r to the smallest of
+// the available representations, including runs which
+// are otherwise never created.
+func (r *Roaring) Optimize() {
+	for i, c := range r.containers {
+		r.containers[i] = optimize(c)
+	}
+}
+
+func (r *Roaring) Union(r1, r2 *Roaring) {
+	r.combine(r1, r2, unionOp)
+}
+
+func (r *Roaring) Intersection(r1, r2 *Roaring) {
+	r.combine(r1, r2, intersectionOp)
+}
+
+func (r *Roaring) Difference(r1, r2 *Roaring) {
+	r.combine(r1, r2, differenceOp)
+}
+
+func (r *Roaring) SymmetricDifference(r1, r2 *Roaring) {
+	r.combine(r1, r2, symmetricDifferenceOp)
+}
+
+func (r *Roaring) combine(r1, r2 *Roaring, op *containerOp) {
+	var (
+		keys       []uint16
+		containers []container
+	)
+
+	i, j := 0, 0
+	for i < len(r1.keys) || j < len(r2.keys) {
+		var (
+			key uint16
+			c   container
+		)
+
+		switch {
+		case j == len(r2.keys) || i < len(r1.keys) && r1.keys[i] < r2.keys[j]:
+			key = r1.keys[i]
+			if op.keep1 {
+				c = r1.containers[i].clone()
+			}
+
+			i++
+		case i == len(r1.keys) || r1.keys[i] > r2.keys[j]:
+			key = r2.keys[j]
+			if op.keep2 {
+				c = r2.containers[j].clone()
+			}
+
+			j++
+		default:
+			key = r1.keys[i]
+			c = op.apply(r1.containers[i], r2.containers[j])
+
+			i++
+			j++
+		}
+
+		if c != nil && c.count() != 0 {
+			keys = append(keys, key)
+			containers = append(containers, c)
+		}
+	}
+
+	r.keys, r.containers = keys, containers
+}
+
+func (r *Roaring) String() string {
+	return fmt.Sprintf("Roaring{%d,%d}", len(r.keys), r.Count())
+}
@@ -0,0 +1,372 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+// These implement the Roaring portable serialization
+// format as described in
+// https://github.com/RoaringBitmap/RoaringFormatSpec.
+const (
+	roaringSerialCookieNoRun = 12346
+	roaringSerialCookie      = 12347
+
+	// roaringNoOffsetThreshold is the number of
+	// containers below which the offset header is
+	// omitted when run containers are present.
+	roaringNoOffsetThreshold = 4
+)
+
+var errRoaringInvalid = errors.New("go-bitset: invalid roaring encoding")
+
+// MarshalBinary encodes r in the Roaring portable
+// serialization format.
+func (r *Roaring) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := r.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data in the Roaring portable
+// serialization format into r.
+func (r *Roaring) UnmarshalBinary(data []byte) error {
+	br := bytes.NewReader(data)
+	if _, err := r.ReadFrom(br); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = errRoaringInvalid
+		}
+
+		return err
+	}
+
+	if br.Len() != 0 {
+		return errRoaringInvalid
+	}
+
+	return nil
+}
+
+// WriteTo writes r to w in the Roaring portable
+// serialization format.
+func (r *Roaring) WriteTo(w io.Writer) (int64, error) {
+	size := len(r.keys)
+
+	var hasRun bool
+	for _, c := range r.containers {
+		if _, ok := c.(runContainer); ok {
+			hasRun = true
+			break
+		}
+	}
+
+	var hdr []byte
+	if hasRun {
+		hdr = make([]byte, 4, 4+(size+7)/8+8*size)
+		binary.LittleEndian.PutUint32(hdr, roaringSerialCookie|uint32(size-1)<<16)
+
+		runs := make([]byte, (size+7)/8)
+		for i, c := range r.containers {
+			if _, ok := c.(runContainer); ok {
+				Bitset(runs).Set(uint(i))
+			}
+		}
+
+		hdr = append(hdr, runs...)
+	} else {
+		hdr = make([]byte, 8, 8+8*size)
+		binary.LittleEndian.PutUint32(hdr, roaringSerialCookieNoRun)
+		binary.LittleEndian.PutUint32(hdr[4:], uint32(size))
+	}
+
+	for i, key := range r.keys {
+		hdr = appendUint16(hdr, key)
+		hdr = appendUint16(hdr, uint16(r.containers[i].count()-1))
+	}
+
+	if !hasRun || size >= roaringNoOffsetThreshold {
+		offset := len(hdr) + 4*size
+
+		for _, c := range r.containers {
+			hdr = appendUint32(hdr, uint32(offset))
+			offset += roaringContainerSize(c)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	n, err := bw.Write(hdr)
+	written := int64(n)
+
+	for _, c := range r.containers {
+		if err != nil {
+			break
+		}
+
+		var buf [4]byte
+
+		switch c := c.(type) {
+		case arrayContainer:
+			for _, x := range c {
+				binary.LittleEndian.PutUint16(buf[:], x)
+				n, err = bw.Write(buf[:2])
+				written += int64(n)
+			}
+		case *bitmapContainer:
+			// The little-endian uint64 words of the format
+			// have the same layout as a Bitset.
+			n, err = bw.Write(c.b)
+			written += int64(n)
+		case runContainer:
+			binary.LittleEndian.PutUint16(buf[:], uint16(len(c)))
+			n, err = bw.Write(buf[:2])
+			written += int64(n)
+
+			for _, iv := range c {
+				binary.LittleEndian.PutUint16(buf[:], iv.start)
+				binary.LittleEndian.PutUint16(buf[2:], iv.last-iv.start)
+				n, err = bw.Write(buf[:])
+				written += int64(n)
+			}
+		}
+	}
+
+	if err != nil {
+		return written, err
+	}
+
+	return written, bw.Flush()
+}
+
+func roaringContainerSize(c container) int {
+	switch c := c.(type) {
+	case arrayContainer:
+		return 2 * len(c)
+	case runContainer:
+		return 2 + 4*len(c)
+	default:
+		return containerBytes
+	}
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// ReadFrom reads a single bitmap in the Roaring portable
+// serialization format from r. Unlike most
+// implementations of io.ReaderFrom, it does not read
+// until io.EOF.
+func (r *Roaring) ReadFrom(rd io.Reader) (int64, error) {
+	cr := &countingReader{r: rd}
+
+	r1, err := readRoaring(cr)
+	if err != nil {
+		if err == io.EOF && cr.n != 0 {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return cr.n, err
+	}
+
+	*r = *r1
+	return cr.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func readRoaring(rd io.Reader) (*Roaring, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(rd, buf[:4]); err != nil {
+		return nil, err
+	}
+
+	var (
+		size       int
+		runs       Bitset
+		hasOffsets = true
+	)
+
+	switch cookie := binary.LittleEndian.Uint32(buf[:]); {
+	case cookie == roaringSerialCookieNoRun:
+		if _, err := io.ReadFull(rd, buf[:4]); err != nil {
+			return nil, err
+		}
+
+		size = int(binary.LittleEndian.Uint32(buf[:]))
+		if size > 1<<16 {
+			return nil, errRoaringInvalid
+		}
+	case cookie&0xffff == roaringSerialCookie:
+		size = int(cookie>>16) + 1
+
+		runs = make(Bitset, (size+7)/8)
+		if _, err := io.ReadFull(rd, runs); err != nil {
+			return nil, err
+		}
+
+		hasOffsets = size >= roaringNoOffsetThreshold
+	default:
+		return nil, errRoaringInvalid
+	}
+
+	hdr := make([]byte, 4*size)
+	if _, err := io.ReadFull(rd, hdr); err != nil {
+		return nil, err
+	}
+
+	if hasOffsets {
+		// The containers are read in order, so the
+		// offsets are not needed.
+		if _, err := io.CopyN(ioutil.Discard, rd, int64(4*size)); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &Roaring{
+		keys:       make([]uint16, size),
+		containers: make([]container, size),
+	}
+
+	for i := range r.keys {
+		r.keys[i] = binary.LittleEndian.Uint16(hdr[4*i:])
+		n := int(binary.LittleEndian.Uint16(hdr[4*i+2:])) + 1
+
+		if i > 0 && r.keys[i] <= r.keys[i-1] {
+			return nil, errRoaringInvalid
+		}
+
+		var (
+			c   container
+			err error
+		)
+
+		switch {
+		case len(runs) != 0 && runs.IsSet(uint(i)):
+			c, err = readRunContainer(rd, n)
+		case n <= arrayMaxSize:
+			c, err = readArrayContainer(rd, n)
+		default:
+			c, err = readBitmapContainer(rd, n)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.containers[i] = c
+	}
+
+	return r, nil
+}
+
+func readArrayContainer(rd io.Reader, n int) (container, error) {
+	data := make([]byte, 2*n)
+	if _, err := io.ReadFull(rd, data); err != nil {
+		return nil, err
+	}
+
+	a := make(arrayContainer, n)
+	for i := range a {
+		a[i] = binary.LittleEndian.Uint16(data[2*i:])
+
+		if i > 0 && a[i] <= a[i-1] {
+			return nil, errRoaringInvalid
+		}
+	}
+
+	return a, nil
+}
+
+func readBitmapContainer(rd io.Reader, n int) (container, error) {
+	b := make(Bitset, containerBytes)
+	if _, err := io.ReadFull(rd, b); err != nil {
+		return nil, err
+	}
+
+	if b.Count() != uint(n) {
+		return nil, errRoaringInvalid
+	}
+
+	return &bitmapContainer{b, n}, nil
+}
+
+func readRunContainer(rd io.Reader, n int) (container, error) {
+	var buf [2]byte
+	if _, err := io.ReadFull(rd, buf[:]); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 4*int(binary.LittleEndian.Uint16(buf[:])))
+	if _, err := io.ReadFull(rd, data); err != nil {
+		return nil, err
+	}
+
+	rc := make(runContainer, len(data)/4)
+
+	var count int
+	for i := range rc {
+		start := binary.LittleEndian.Uint16(data[4*i:])
+		length := binary.LittleEndian.Uint16(data[4*i+2:])
+
+		if uint32(start)+uint32(length) > 0xffff ||
+			i > 0 && start <= rc[i-1].last {
+			return nil, errRoaringInvalid
+		}
+
+		rc[i] = interval{start, start + length}
+		count += int(length) + 1
+	}
+
+	if count != n {
+		return nil, errRoaringInvalid
+	}
+
+	return rc, nil
+}
+
+// MarshalRoaring encodes b in the Roaring portable
+// serialization format, using whichever container
+// representation is smallest for each 64K chunk.
+func (b Bitset) MarshalRoaring() ([]byte, error) {
+	r := NewRoaringFromBitset(b)
+	r.Optimize()
+	return r.MarshalBinary()
+}
+
+// UnmarshalRoaring decodes data in the Roaring portable
+// serialization format into b. The result is just long
+// enough to hold the highest set bit.
+func (b *Bitset) UnmarshalRoaring(data []byte) error {
+	var r Roaring
+	if err := r.UnmarshalBinary(data); err != nil {
+		return err
+	}
+
+	*b = r.Bitset()
+	return nil
+}
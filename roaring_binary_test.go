@@ -0,0 +1,234 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/quick"
+
+	"github.com/tmthrgd/go-hex"
+)
+
+// roaringBitmapFixture holds 0 through 4096, which is
+// stored in a single bitmap container.
+var roaringBitmapFixture = append(hex.MustDecodeString(""+
+	"3a300000"+ // cookie
+	"01000000"+ // size
+	"00000010"+ // key 0, cardinality 4097
+	"10000000"), // offset 16
+	append(append(bytes.Repeat([]byte{0xff}, 512), 0x01), make([]byte, 8192-513)...)...)
+
+var roaringFixtures = []struct {
+	name string
+	data []byte
+	bits []uint
+	opt  bool
+}{
+	{
+		"empty",
+		hex.MustDecodeString("3a300000" + "00000000"),
+		nil,
+		false,
+	},
+	{
+		"array",
+		hex.MustDecodeString("" +
+			"3a300000" + // cookie
+			"02000000" + // size
+			"00000100" + // key 0, cardinality 2
+			"01000000" + // key 1, cardinality 1
+			"18000000" + // offset 24
+			"1c000000" + // offset 28
+			"01000200" + // 1, 2
+			"0500"), // 65536+5
+		[]uint{1, 2, 1<<16 + 5},
+		false,
+	},
+	{
+		"run",
+		hex.MustDecodeString("" +
+			"3b300000" + // cookie, size 1
+			"01" + // run bitset
+			"00006400" + // key 0, cardinality 101
+			"0200" + // 2 runs
+			"00006300" + // 0-99
+			"c8000000"), // 200
+		append(testRange(0, 100), 200),
+		true,
+	},
+	{
+		"run offsets",
+		hex.MustDecodeString("" +
+			"3b300300" + // cookie, size 4
+			"05" + // run bitset
+			"00000000" + // key 0, cardinality 1
+			"01000000" + // key 1, cardinality 1
+			"02000900" + // key 2, cardinality 10
+			"03000000" + // key 3, cardinality 1
+			"25000000" + // offset 37
+			"2b000000" + // offset 43
+			"2d000000" + // offset 45
+			"33000000" + // offset 51
+			"0100" + "07000000" + // 1 run: 7
+			"0800" + // 65536+8
+			"0100" + "0a000900" + // 1 run: 131072+10-19
+			"0900"), // 196608+9
+		append(append([]uint{7, 1<<16 + 8}, testRange(2<<16+10, 2<<16+20)...), 3<<16+9),
+		false,
+	},
+	{
+		"bitmap",
+		roaringBitmapFixture,
+		testRange(0, 4097),
+		false,
+	},
+}
+
+func testRange(start, end uint) (bits []uint) {
+	for i := start; i < end; i++ {
+		bits = append(bits, i)
+	}
+
+	return
+}
+
+func TestRoaringUnmarshalBinary(t *testing.T) {
+	for _, v := range roaringFixtures {
+		var r Roaring
+		if err := r.UnmarshalBinary(v.data); err != nil {
+			t.Errorf("UnmarshalBinary failed for %s fixture: %v", v.name, err)
+			continue
+		}
+
+		r1 := NewRoaring()
+		for _, bit := range v.bits {
+			r1.Set(bit)
+		}
+
+		if !r.Equal(r1) {
+			t.Errorf("UnmarshalBinary failed for %s fixture, expected %d bits, got %d", v.name, r1.Count(), r.Count())
+		}
+
+		var b Bitset
+		if err := b.UnmarshalRoaring(v.data); err != nil || !b.Equal(r1.Bitset()) {
+			t.Errorf("UnmarshalRoaring failed for %s fixture", v.name)
+		}
+	}
+}
+
+func TestRoaringMarshalBinary(t *testing.T) {
+	for _, v := range roaringFixtures {
+		if v.name == "run offsets" {
+			// Optimize would store the single values
+			// as arrays rather than runs.
+			continue
+		}
+
+		r := NewRoaring()
+		for _, bit := range v.bits {
+			r.Set(bit)
+		}
+
+		if v.opt {
+			r.Optimize()
+		}
+
+		data, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(data, v.data) {
+			t.Errorf("MarshalBinary failed for %s fixture, expected %x, got %x", v.name, v.data, data)
+		}
+	}
+
+	var r Roaring
+	r.UnmarshalBinary(roaringFixtures[3].data)
+
+	if data, _ := r.MarshalBinary(); !bytes.Equal(data, roaringFixtures[3].data) {
+		t.Errorf("MarshalBinary failed for run offsets fixture, expected %x, got %x", roaringFixtures[3].data, data)
+	}
+}
+
+func TestRoaringMarshalBinaryRoundTrip(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		r := NewRoaringFromBitset(b)
+		if b[0]&1 != 0 {
+			r.Optimize()
+		}
+
+		data, err := r.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		var r1 Roaring
+		if r1.UnmarshalBinary(data) != nil || !r1.Equal(r) {
+			return false
+		}
+
+		data, err = b.MarshalRoaring()
+		if err != nil {
+			return false
+		}
+
+		var b1 Bitset
+		return b1.UnmarshalRoaring(data) == nil && testRoaringEqual(NewRoaringFromBitset(b1), b)
+	}, &quick.Config{
+		Values:        roaringTestValues,
+		MaxCountScale: 0.5,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoaringUnmarshalBinaryInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		hex.MustDecodeString("3a3000"),
+		hex.MustDecodeString("39300000" + "00000000"),
+		hex.MustDecodeString("3a300000" + "01000000" + "00000100" + "10000000" + "0100"),
+		hex.MustDecodeString("3a300000" + "01000000" + "00000100" + "10000000" + "02000100"),
+		hex.MustDecodeString("3a300000" + "02000000" + "01000000" + "00000000" + "18000000" + "1a000000" + "0100" + "0100"),
+		hex.MustDecodeString("3b300000" + "01" + "00000100" + "0100" + "00000200"),
+		hex.MustDecodeString("3b300000" + "01" + "00000300" + "0200" + "00000100" + "01000100"),
+		hex.MustDecodeString("3b300000" + "01" + "00000100" + "0100" + "ffff0100"),
+		hex.MustDecodeString("3a300000" + "00000000" + "00"),
+		roaringBitmapFixture[:len(roaringBitmapFixture)-1],
+		append(roaringBitmapFixture[:16:16], make([]byte, 8192)...),
+	} {
+		var r Roaring
+		if err := r.UnmarshalBinary(data); err != errRoaringInvalid {
+			t.Errorf("UnmarshalBinary did not fail for %x, got %v", data, err)
+		}
+	}
+}
+
+func TestRoaringReadFrom(t *testing.T) {
+	var buf bytes.Buffer
+	for _, v := range roaringFixtures {
+		buf.Write(v.data)
+	}
+
+	for _, v := range roaringFixtures {
+		var r Roaring
+		if n, err := r.ReadFrom(&buf); err != nil || n != int64(len(v.data)) {
+			t.Fatalf("ReadFrom failed for %s fixture: %v", v.name, err)
+		}
+
+		if r.Count() != uint(len(v.bits)) {
+			t.Errorf("ReadFrom failed for %s fixture, expected %d bits, got %d", v.name, len(v.bits), r.Count())
+		}
+	}
+
+	var r Roaring
+	if _, err := r.ReadFrom(&buf); err != io.EOF {
+		t.Errorf("ReadFrom failed, expected %v, got %v", io.EOF, err)
+	}
+}
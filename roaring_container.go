@@ -0,0 +1,352 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "sort"
+
+const (
+	containerBits  = 1 << 16
+	containerBytes = containerBits >> 3
+
+	// arrayMaxSize is the largest cardinality stored in
+	// an arrayContainer, beyond it a bitmapContainer is
+	// smaller.
+	arrayMaxSize = 4096
+)
+
+// container holds the low 16 bits of the values in a
+// single 64K chunk of a Roaring bitmap.
+type container interface {
+	contains(x uint16) bool
+	add(x uint16) container
+	remove(x uint16) container
+	count() int
+
+	// bitmap returns the container as a Bitset of
+	// containerBytes. The result must not be modified.
+	bitmap() Bitset
+
+	clone() container
+}
+
+// arrayContainer is a sorted list of values.
+type arrayContainer []uint16
+
+// bitmapContainer is a dense Bitset of containerBytes.
+type bitmapContainer struct {
+	b Bitset
+	n int
+}
+
+// runContainer is a sorted list of non-overlapping,
+// non-adjacent runs of values.
+type runContainer []interval
+
+type interval struct {
+	start, last uint16
+}
+
+// newContainer returns the smaller of an array or
+// bitmap container holding the n bits set in b. It
+// takes ownership of b.
+func newContainer(b Bitset, n int) container {
+	if n > arrayMaxSize {
+		return &bitmapContainer{b, n}
+	}
+
+	a := make(arrayContainer, 0, n)
+	for bit, ok := b.NextSet(0); ok; bit, ok = b.NextSet(bit + 1) {
+		a = append(a, uint16(bit))
+	}
+
+	return a
+}
+
+func (a arrayContainer) search(x uint16) int {
+	return sort.Search(len(a), func(i int) bool {
+		return a[i] >= x
+	})
+}
+
+func (a arrayContainer) contains(x uint16) bool {
+	i := a.search(x)
+	return i < len(a) && a[i] == x
+}
+
+func (a arrayContainer) add(x uint16) container {
+	i := a.search(x)
+	if i < len(a) && a[i] == x {
+		return a
+	}
+
+	if len(a) >= arrayMaxSize {
+		b := a.bitmap()
+		b.Set(uint(x))
+		return &bitmapContainer{b, len(a) + 1}
+	}
+
+	a = append(a, 0)
+	copy(a[i+1:], a[i:])
+	a[i] = x
+	return a
+}
+
+func (a arrayContainer) remove(x uint16) container {
+	i := a.search(x)
+	if i == len(a) || a[i] != x {
+		return a
+	}
+
+	return append(a[:i], a[i+1:]...)
+}
+
+func (a arrayContainer) count() int {
+	return len(a)
+}
+
+func (a arrayContainer) bitmap() Bitset {
+	b := make(Bitset, containerBytes)
+	for _, x := range a {
+		b.Set(uint(x))
+	}
+
+	return b
+}
+
+func (a arrayContainer) clone() container {
+	return append(arrayContainer(nil), a...)
+}
+
+func (bc *bitmapContainer) contains(x uint16) bool {
+	return bc.b.IsSet(uint(x))
+}
+
+func (bc *bitmapContainer) add(x uint16) container {
+	if !bc.b.IsSet(uint(x)) {
+		bc.b.Set(uint(x))
+		bc.n++
+	}
+
+	return bc
+}
+
+func (bc *bitmapContainer) remove(x uint16) container {
+	if !bc.b.IsSet(uint(x)) {
+		return bc
+	}
+
+	bc.b.Clear(uint(x))
+	bc.n--
+
+	if bc.n <= arrayMaxSize {
+		return newContainer(bc.b, bc.n)
+	}
+
+	return bc
+}
+
+func (bc *bitmapContainer) count() int {
+	return bc.n
+}
+
+func (bc *bitmapContainer) bitmap() Bitset {
+	return bc.b
+}
+
+func (bc *bitmapContainer) clone() container {
+	return &bitmapContainer{bc.b.Clone(), bc.n}
+}
+
+func (rc runContainer) contains(x uint16) bool {
+	i := sort.Search(len(rc), func(i int) bool {
+		return rc[i].last >= x
+	})
+	return i < len(rc) && rc[i].start <= x
+}
+
+func (rc runContainer) add(x uint16) container {
+	if rc.contains(x) {
+		return rc
+	}
+
+	b := rc.bitmap()
+	b.Set(uint(x))
+	return newContainer(b, rc.count()+1)
+}
+
+func (rc runContainer) remove(x uint16) container {
+	if !rc.contains(x) {
+		return rc
+	}
+
+	b := rc.bitmap()
+	b.Clear(uint(x))
+	return newContainer(b, rc.count()-1)
+}
+
+func (rc runContainer) count() (n int) {
+	for _, iv := range rc {
+		n += int(iv.last-iv.start) + 1
+	}
+
+	return
+}
+
+func (rc runContainer) bitmap() Bitset {
+	b := make(Bitset, containerBytes)
+	for _, iv := range rc {
+		b.SetRange(uint(iv.start), uint(iv.last)+1)
+	}
+
+	return b
+}
+
+func (rc runContainer) clone() container {
+	return append(runContainer(nil), rc...)
+}
+
+// runs returns the runs of set bits in c.
+func runs(c container) runContainer {
+	if rc, ok := c.(runContainer); ok {
+		return rc
+	}
+
+	var rc runContainer
+
+	if a, ok := c.(arrayContainer); ok {
+		for i := 0; i < len(a); {
+			j := i
+			for j+1 < len(a) && a[j+1] == a[j]+1 {
+				j++
+			}
+
+			rc = append(rc, interval{a[i], a[j]})
+			i = j + 1
+		}
+
+		return rc
+	}
+
+	b := c.bitmap()
+	for start, ok := b.NextSet(0); ok; start, ok = b.NextSet(start) {
+		end, ok := b.NextClear(start)
+		if !ok {
+			end = containerBits
+		}
+
+		rc = append(rc, interval{uint16(start), uint16(end - 1)})
+		start = end
+	}
+
+	return rc
+}
+
+// optimize returns the smallest representation of c.
+func optimize(c container) container {
+	rc := runs(c)
+
+	n := c.count()
+	size := 2 * n
+	if n > arrayMaxSize {
+		size = containerBytes
+	}
+
+	if 2+4*len(rc) < size {
+		return rc
+	}
+
+	if _, ok := c.(runContainer); ok {
+		return newContainer(c.bitmap().Clone(), n)
+	}
+
+	return c
+}
+
+// mergeArrays combines two sorted arrays, keeping values
+// only in a1, in both, or only in a2 as requested.
+func mergeArrays(a1, a2 arrayContainer, keep1, keepBoth, keep2 bool) arrayContainer {
+	var a arrayContainer
+
+	i, j := 0, 0
+	for i < len(a1) && j < len(a2) {
+		switch {
+		case a1[i] < a2[j]:
+			if keep1 {
+				a = append(a, a1[i])
+			}
+
+			i++
+		case a1[i] > a2[j]:
+			if keep2 {
+				a = append(a, a2[j])
+			}
+
+			j++
+		default:
+			if keepBoth {
+				a = append(a, a1[i])
+			}
+
+			i++
+			j++
+		}
+	}
+
+	if keep1 {
+		a = append(a, a1[i:]...)
+	}
+
+	if keep2 {
+		a = append(a, a2[j:]...)
+	}
+
+	return a
+}
+
+// containerOp implements a binary set operation over
+// containers.
+type containerOp struct {
+	keep1, keepBoth, keep2 bool
+
+	bitmap func(b, b1, b2 Bitset)
+}
+
+var (
+	unionOp = &containerOp{true, true, true, Bitset.Union}
+
+	intersectionOp = &containerOp{false, true, false, Bitset.Intersection}
+
+	differenceOp = &containerOp{true, false, false, Bitset.Difference}
+
+	symmetricDifferenceOp = &containerOp{true, false, true, Bitset.SymmetricDifference}
+)
+
+func (op *containerOp) apply(c1, c2 container) container {
+	a1, ok1 := c1.(arrayContainer)
+	a2, ok2 := c2.(arrayContainer)
+
+	switch {
+	case ok1 && ok2:
+		if a := mergeArrays(a1, a2, op.keep1, op.keepBoth, op.keep2); len(a) <= arrayMaxSize {
+			return a
+		}
+	case ok1 && !op.keep2:
+		// Intersection and Difference with an array on
+		// the left can only remove values from it.
+		var a arrayContainer
+		for _, x := range a1 {
+			if c2.contains(x) == op.keepBoth {
+				a = append(a, x)
+			}
+		}
+
+		return a
+	}
+
+	b := make(Bitset, containerBytes)
+	op.bitmap(b, c1.bitmap(), c2.bitmap())
+	return newContainer(b, int(b.Count()))
+}
@@ -0,0 +1,259 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// roaringTestValues generates a dense Bitset spanning
+// several containers, each of which is empty, sparse,
+// dense, random or made of long runs.
+func roaringTestValues(args []reflect.Value, rand *rand.Rand) {
+	for i := range args {
+		b := New(uint(1+rand.Intn(5)) * containerBits)
+
+		for j := uint(0); j < b.Len(); j += containerBits {
+			switch rand.Intn(5) {
+			case 1:
+				for n := rand.Intn(arrayMaxSize); n > 0; n-- {
+					b.Set(j + uint(rand.Intn(containerBits)))
+				}
+			case 2:
+				b.SetRange(j, j+containerBits)
+				for n := rand.Intn(arrayMaxSize); n > 0; n-- {
+					b.Clear(j + uint(rand.Intn(containerBits)))
+				}
+			case 3:
+				rand.Read(b[j>>3 : (j+containerBits)>>3])
+			case 4:
+				for n := rand.Intn(16); n > 0; n-- {
+					start := j + uint(rand.Intn(containerBits))
+					end := start + uint(rand.Intn(int(j+containerBits-start)))
+					b.SetRange(start, end)
+				}
+			}
+		}
+
+		args[i] = reflect.ValueOf(b)
+	}
+}
+
+func testRoaringEqual(r *Roaring, b Bitset) bool {
+	b1 := r.Bitset()
+	return b1.Equal(b[:len(b1)]) && b[len(b1):].None() && r.Count() == b.Count()
+}
+
+func TestRoaringSet(t *testing.T) {
+	r := NewRoaring()
+
+	for _, bit := range []uint{0, 1, 70000, 1<<32 - 1, 1} {
+		r.Set(bit)
+
+		if !r.IsSet(bit) {
+			t.Errorf("Set failed, should have found bit #%d", bit)
+		}
+	}
+
+	if r.Count() != 4 {
+		t.Errorf("invalid count, expected 4, got %d", r.Count())
+	}
+
+	if r.IsSet(2) || r.IsSet(70001) {
+		t.Error("IsSet failed, found unexpected bit")
+	}
+
+	r.Clear(70000)
+	r.Clear(70001)
+
+	if r.IsSet(70000) || r.Count() != 3 || len(r.keys) != 2 {
+		t.Error("Clear failed")
+	}
+
+	if ^uint(0) == roaringMax {
+		return
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Set did not panic for out of range bit")
+		}
+	}()
+
+	bit := uint64(roaringMax) + 1
+	r.Set(uint(bit))
+}
+
+func TestRoaringContainers(t *testing.T) {
+	r := NewRoaring()
+
+	for i := uint(0); i <= arrayMaxSize; i++ {
+		r.Set(i * 2)
+	}
+
+	if _, ok := r.containers[0].(*bitmapContainer); !ok {
+		t.Errorf("Set failed, expected bitmap container, got %T", r.containers[0])
+	}
+
+	r.Clear(0)
+
+	if _, ok := r.containers[0].(arrayContainer); !ok {
+		t.Errorf("Clear failed, expected array container, got %T", r.containers[0])
+	}
+
+	r = NewRoaringFromBitset(New(containerBits))
+	for i := uint(100); i < 60000; i++ {
+		r.Set(i)
+	}
+
+	r.Optimize()
+
+	if _, ok := r.containers[0].(runContainer); !ok {
+		t.Errorf("Optimize failed, expected run container, got %T", r.containers[0])
+	}
+
+	r.Set(60001)
+	r.Clear(500)
+
+	b := New(containerBits)
+	b.SetRange(100, 60000)
+	b.Set(60001)
+	b.Clear(500)
+
+	if !testRoaringEqual(r, b) {
+		t.Error("Set failed on run container")
+	}
+}
+
+func TestRoaringBitset(t *testing.T) {
+	if err := quick.Check(func(b Bitset) bool {
+		r := NewRoaringFromBitset(b)
+		if !testRoaringEqual(r, b) {
+			return false
+		}
+
+		r.Optimize()
+
+		for i := 0; i < 100; i++ {
+			bit := uint(rand.Intn(int(b.Len())))
+			if r.IsSet(bit) != b.IsSet(bit) {
+				return false
+			}
+		}
+
+		return testRoaringEqual(r, b)
+	}, &quick.Config{
+		Values:        roaringTestValues,
+		MaxCountScale: 0.5,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoaringBitsetTooLarge(t *testing.T) {
+	if ^uint(0) != roaringMax {
+		// A Bitset holding bit 1<<32-1 only fails to
+		// fit on 32-bit platforms.
+		return
+	}
+
+	r := NewRoaring()
+	r.Set(roaringMax)
+
+	defer func() {
+		if err := recover(); err != errOutOfRange {
+			t.Errorf("Bitset did not panic with %v, got %v", errOutOfRange, err)
+		}
+	}()
+
+	r.Bitset()
+}
+
+func TestRoaringBitwise(t *testing.T) {
+	for _, v := range []struct {
+		name string
+		fn   func(r, r1, r2 *Roaring)
+		fn1  func(b, b1, b2 Bitset)
+	}{
+		{"Union", (*Roaring).Union, Bitset.Union},
+		{"Intersection", (*Roaring).Intersection, Bitset.Intersection},
+		{"Difference", (*Roaring).Difference, Bitset.Difference},
+		{"SymmetricDifference", (*Roaring).SymmetricDifference, Bitset.SymmetricDifference},
+	} {
+		if err := quick.Check(func(b1, b2 Bitset) bool {
+			l := b1.Len()
+			if b2.Len() > l {
+				l = b2.Len()
+			}
+
+			b1, b2 = append(b1, make(Bitset, (l-b1.Len())>>3)...), append(b2, make(Bitset, (l-b2.Len())>>3)...)
+
+			r1, r2 := NewRoaringFromBitset(b1), NewRoaringFromBitset(b2)
+			if rand.Intn(2) == 0 {
+				r1.Optimize()
+			}
+
+			r := NewRoaring()
+			v.fn(r, r1, r2)
+
+			b := New(l)
+			v.fn1(b, b1, b2)
+
+			return testRoaringEqual(r, b) && testRoaringEqual(r1, b1) && testRoaringEqual(r2, b2)
+		}, &quick.Config{
+			Values:        roaringTestValues,
+			MaxCountScale: 0.5,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestRoaringEqual(t *testing.T) {
+	b := New(3 * containerBits)
+	b.SetRange(1000, 70000)
+	b.Set(150000)
+
+	r, r1 := NewRoaringFromBitset(b), NewRoaringFromBitset(b)
+	r1.Optimize()
+
+	if !r.Equal(r1) || !r.Equal(r.Clone()) {
+		t.Error("Equal failed")
+	}
+
+	r1.Clear(150000)
+
+	if r.Equal(r1) {
+		t.Error("Equal failed")
+	}
+}
+
+func BenchmarkRoaringSet(b *testing.B) {
+	r := NewRoaring()
+
+	for i := 0; i < b.N; i++ {
+		r.Set(uint(i*7919) & roaringMax)
+	}
+}
+
+func BenchmarkRoaringUnion(b *testing.B) {
+	bs1, bs2 := New(1<<24), New(1<<24)
+	rand.Read(bs1[:len(bs1)/2])
+	for i := uint(0); i < bs2.Len(); i += 1000 {
+		bs2.Set(i)
+	}
+
+	r, r1, r2 := NewRoaring(), NewRoaringFromBitset(bs1), NewRoaringFromBitset(bs2)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r.Union(r1, r2)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/tmthrgd/go-hex"
+)
+
+var errLengthMismatch = errors.New("go-bitset: length mismatch")
+
+// Sized is a Bitset with an exact length in bits.
+//
+// Unlike a Bitset, whose length is always a multiple of
+// 8, the bits of the final byte past Len are always
+// clear and are ignored by All, InvertAll, Count, Equal
+// and String.
+type Sized struct {
+	b Bitset
+	n uint
+}
+
+func NewSized(size uint) *Sized {
+	return &Sized{New(size), size}
+}
+
+// NewSizedFromBitset returns a copy of the first size
+// bits of b.
+func NewSizedFromBitset(b Bitset, size uint) *Sized {
+	if size > b.Len() {
+		panic(errOutOfRange)
+	}
+
+	s := &Sized{b[:(size+7)>>3].Clone(), size}
+	s.clearPadding()
+	return s
+}
+
+func (s *Sized) clearPadding() {
+	if s.n&7 != 0 {
+		s.b[s.n>>3] &= 1<<(s.n&7) - 1
+	}
+}
+
+func (s *Sized) Len() uint {
+	return s.n
+}
+
+func (s *Sized) ByteLen() int {
+	return len(s.b)
+}
+
+// Bitset returns the underlying Bitset. Any padding bits
+// past Len must be left clear.
+func (s *Sized) Bitset() Bitset {
+	return s.b
+}
+
+func (s *Sized) Clone() *Sized {
+	return &Sized{s.b.Clone(), s.n}
+}
+
+func (s *Sized) checkBit(bit uint) {
+	if bit >= s.n {
+		panic(errOutOfRange)
+	}
+}
+
+func (s *Sized) checkRange(start, end uint) {
+	if start > end {
+		panic(errEndLessThanStart)
+	}
+
+	if end > s.n {
+		panic(errOutOfRange)
+	}
+}
+
+func (s *Sized) checkLen(s1 *Sized) {
+	if s1.n != s.n {
+		panic(errLengthMismatch)
+	}
+}
+
+func (s *Sized) IsSet(bit uint) bool {
+	s.checkBit(bit)
+	return s.b.IsSet(bit)
+}
+
+func (s *Sized) IsClear(bit uint) bool {
+	return !s.IsSet(bit)
+}
+
+func (s *Sized) GetBits(offset, width uint) uint64 {
+	checkBits(s.n, offset, width)
+	return s.b.GetBits(offset, width)
+}
+
+func (s *Sized) GetSignedBits(offset, width uint) int64 {
+	checkBits(s.n, offset, width)
+	return s.b.GetSignedBits(offset, width)
+}
+
+func (s *Sized) PutBits(offset, width uint, value uint64) {
+	checkBits(s.n, offset, width)
+	s.b.PutBits(offset, width, value)
+}
+
+func (s *Sized) PutSignedBits(offset, width uint, value int64) {
+	checkBits(s.n, offset, width)
+	s.b.PutSignedBits(offset, width, value)
+}
+
+func (s *Sized) Set(bit uint) {
+	s.checkBit(bit)
+	s.b.Set(bit)
+}
+
+func (s *Sized) Clear(bit uint) {
+	s.checkBit(bit)
+	s.b.Clear(bit)
+}
+
+func (s *Sized) Invert(bit uint) {
+	s.checkBit(bit)
+	s.b.Invert(bit)
+}
+
+func (s *Sized) SetTo(bit uint, value bool) {
+	s.checkBit(bit)
+	s.b.SetTo(bit, value)
+}
+
+func (s *Sized) SetRange(start, end uint) {
+	s.checkRange(start, end)
+	s.b.SetRange(start, end)
+}
+
+func (s *Sized) ClearRange(start, end uint) {
+	s.checkRange(start, end)
+	s.b.ClearRange(start, end)
+}
+
+func (s *Sized) InvertRange(start, end uint) {
+	s.checkRange(start, end)
+	s.b.InvertRange(start, end)
+}
+
+func (s *Sized) SetRangeTo(start, end uint, value bool) {
+	s.checkRange(start, end)
+	s.b.SetRangeTo(start, end, value)
+}
+
+func (s *Sized) IsRangeSet(start, end uint) bool {
+	s.checkRange(start, end)
+	return s.b.IsRangeSet(start, end)
+}
+
+func (s *Sized) IsRangeClear(start, end uint) bool {
+	s.checkRange(start, end)
+	return s.b.IsRangeClear(start, end)
+}
+
+func (s *Sized) SetAll() {
+	s.b.SetAll()
+	s.clearPadding()
+}
+
+func (s *Sized) ClearAll() {
+	s.b.ClearAll()
+}
+
+func (s *Sized) InvertAll() {
+	s.b.InvertAll()
+	s.clearPadding()
+}
+
+func (s *Sized) All() bool {
+	return s.b.IsRangeSet(0, s.n)
+}
+
+func (s *Sized) None() bool {
+	return s.b.None()
+}
+
+func (s *Sized) Any() bool {
+	return !s.None()
+}
+
+func (s *Sized) Count() uint {
+	return s.b.Count()
+}
+
+func (s *Sized) CountRange(start, end uint) uint {
+	s.checkRange(start, end)
+	return s.b.CountRange(start, end)
+}
+
+func (s *Sized) Equal(s1 *Sized) bool {
+	return s.n == s1.n && s.b.Equal(s1.b)
+}
+
+func (s *Sized) Complement(s1 *Sized) {
+	s.checkLen(s1)
+	s.b.Complement(s1.b)
+	s.clearPadding()
+}
+
+func (s *Sized) Union(s1, s2 *Sized) {
+	s.checkLen(s1)
+	s.checkLen(s2)
+	s.b.Union(s1.b, s2.b)
+}
+
+func (s *Sized) Intersection(s1, s2 *Sized) {
+	s.checkLen(s1)
+	s.checkLen(s2)
+	s.b.Intersection(s1.b, s2.b)
+}
+
+func (s *Sized) Difference(s1, s2 *Sized) {
+	s.checkLen(s1)
+	s.checkLen(s2)
+	s.b.Difference(s1.b, s2.b)
+}
+
+func (s *Sized) SymmetricDifference(s1, s2 *Sized) {
+	s.checkLen(s1)
+	s.checkLen(s2)
+	s.b.SymmetricDifference(s1.b, s2.b)
+}
+
+func (s *Sized) String() string {
+	const maxSize = 128
+
+	if len(s.b) > maxSize {
+		return "Sized{" + strconv.FormatUint(uint64(s.n), 10) + "," + hex.EncodeToString(s.b[:maxSize]) + "...}"
+	}
+
+	return "Sized{" + strconv.FormatUint(uint64(s.n), 10) + "," + hex.EncodeToString(s.b) + "}"
+}
+
+// MarshalBinary encodes s in the same format as
+// Bitset.MarshalBinary, but with the exact length.
+func (s *Sized) MarshalBinary() ([]byte, error) {
+	return appendBinary(nil, uint64(s.n), func(payload []byte) {
+		copy(payload, s.b)
+	}), nil
+}
+
+func (s *Sized) UnmarshalBinary(data []byte) error {
+	payload, bits, err := decodeBinary(data)
+	if err != nil {
+		return err
+	}
+
+	if bits > uint64(^uint(0)) {
+		return errBinaryTooLarge
+	}
+
+	s.b, s.n = Bitset(payload).Clone(), uint(bits)
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "github.com/tmthrgd/go-memset"
+
+// Grow ensures there is capacity for another n bits
+// without reallocating. It does not change Len.
+func (s *Sized) Grow(n uint) {
+	if n > ^uint(0)-7-s.n {
+		panic(errOutOfRange)
+	}
+
+	if need := int((s.n + n + 7) >> 3); need > cap(s.b) {
+		s.realloc(need)
+	}
+}
+
+func (s *Sized) realloc(need int) {
+	c := 2 * cap(s.b)
+	if c < need {
+		c = need
+	}
+
+	b := make(Bitset, len(s.b), c)
+	copy(b, s.b)
+	s.b = b
+}
+
+// Resize sets the length of s to size bits. Any new bits
+// are clear.
+func (s *Sized) Resize(size uint) {
+	if size > ^uint(0)-7 {
+		panic(errOutOfRange)
+	}
+
+	l := int((size + 7) >> 3)
+
+	if size < s.n {
+		memset.Memset(s.b[l:], 0)
+		s.b, s.n = s.b[:l], size
+		s.clearPadding()
+		return
+	}
+
+	if l > cap(s.b) {
+		s.realloc(l)
+	}
+
+	old := len(s.b)
+	s.b, s.n = s.b[:l], size
+	memset.Memset(s.b[old:], 0)
+}
+
+// Truncate shortens s to size bits.
+func (s *Sized) Truncate(size uint) {
+	if size > s.n {
+		panic(errOutOfRange)
+	}
+
+	s.Resize(size)
+}
+
+func (s *Sized) AppendBit(value bool) {
+	s.Resize(s.n + 1)
+
+	if value {
+		s.b.Set(s.n - 1)
+	}
+}
+
+// AppendBits appends the low width bits of value, least
+// significant bit first. width must be at most 64.
+func (s *Sized) AppendBits(value uint64, width uint) {
+	if width > 64 {
+		panic(errInvalidWidth)
+	}
+
+	off := s.n
+	s.Resize(s.n + width)
+	s.b.PutBits(off, width, value)
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import "testing"
+
+func TestSizedResize(t *testing.T) {
+	s := NewSized(20)
+	s.SetAll()
+
+	s.Resize(13)
+
+	if s.Len() != 13 || s.Count() != 13 || s.ByteLen() != 2 {
+		t.Errorf("Resize failed to shrink, got %s", s)
+	}
+
+	s.Resize(100)
+
+	if s.Len() != 100 || s.Count() != 13 || !s.IsRangeClear(13, 100) {
+		t.Errorf("Resize failed to grow, new bits should be clear, got %s", s)
+	}
+
+	s.Truncate(0)
+
+	if s.Len() != 0 || s.ByteLen() != 0 {
+		t.Errorf("Truncate failed, got %s", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Truncate did not panic when growing")
+		}
+	}()
+
+	s.Truncate(1)
+}
+
+func TestSizedGrow(t *testing.T) {
+	s := NewSized(10)
+	s.Grow(1000)
+
+	if s.Len() != 10 {
+		t.Errorf("Grow failed, changed Len to %d", s.Len())
+	}
+
+	b := s.Bitset()
+	s.Resize(1010)
+
+	if &s.Bitset()[0] != &b[0] {
+		t.Error("Grow failed, Resize reallocated")
+	}
+
+	for _, n := range []uint{^uint(0), ^uint(0) - 1010} {
+		func() {
+			defer func() {
+				if err := recover(); err != errOutOfRange {
+					t.Errorf("Grow(%d) did not panic with %v, got %v", n, errOutOfRange, err)
+				}
+			}()
+
+			s.Grow(n)
+		}()
+	}
+}
+
+func TestSizedAppend(t *testing.T) {
+	s := NewSized(0)
+
+	for i := 0; i < 100; i++ {
+		s.AppendBit(i%3 == 0)
+	}
+
+	for i := uint(0); i < 100; i++ {
+		if s.IsSet(i) != (i%3 == 0) {
+			t.Fatalf("AppendBit failed at bit #%d", i)
+		}
+	}
+
+	s.AppendBits(0x1234567890abcdef, 64)
+	s.AppendBits(0xff, 3)
+	s.AppendBits(0, 0)
+
+	if s.Len() != 167 || s.Count() != 34+32+3 {
+		t.Errorf("AppendBits failed, got %s", s)
+	}
+
+	for i := uint(0); i < 64; i++ {
+		if s.IsSet(100+i) != (uint64(0x1234567890abcdef)>>i&1 != 0) {
+			t.Fatalf("AppendBits failed at bit #%d", 100+i)
+		}
+	}
+}
+
+func BenchmarkSizedAppendBit(b *testing.B) {
+	s := NewSized(0)
+
+	for i := 0; i < b.N; i++ {
+		s.AppendBit(i&1 != 0)
+	}
+}
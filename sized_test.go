@@ -0,0 +1,172 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestNewSized(t *testing.T) {
+	for _, size := range []uint{0, 1, 7, 8, 9, 100} {
+		s := NewSized(size)
+
+		if s.Len() != size {
+			t.Errorf("NewSized failed for size %d, got Len of %d", size, s.Len())
+		}
+
+		if s.ByteLen() != int(size+7)/8 {
+			t.Errorf("NewSized failed for size %d, got ByteLen of %d", size, s.ByteLen())
+		}
+	}
+}
+
+func TestSizedAll(t *testing.T) {
+	s := NewSized(100)
+	s.SetRange(0, 100)
+
+	if !s.All() {
+		t.Error("All failed, SetRange(0, Len) should set all bits")
+	}
+
+	if s.Count() != 100 {
+		t.Errorf("invalid count, expected 100, got %d", s.Count())
+	}
+
+	s.ClearAll()
+	s.SetAll()
+
+	if !s.All() || s.Count() != 100 || s.Bitset()[12] != 0x0f {
+		t.Error("SetAll failed, padding bits should be clear")
+	}
+
+	s.InvertAll()
+
+	if !s.None() || s.Bitset()[12] != 0 {
+		t.Error("InvertAll failed, padding bits should be clear")
+	}
+
+	s1 := NewSized(100)
+	s1.Complement(s)
+
+	if !s1.All() || s1.Bitset()[12] != 0x0f {
+		t.Error("Complement failed, padding bits should be clear")
+	}
+}
+
+func TestSizedOutOfRange(t *testing.T) {
+	s := NewSized(100)
+
+	for _, fn := range []func(){
+		func() { s.Set(100) },
+		func() { s.IsSet(103) },
+		func() { s.SetRange(0, 101) },
+		func() { s.Union(s, NewSized(101)) },
+		func() { NewSizedFromBitset(New(8), 9) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic for out of range bit")
+				}
+			}()
+
+			fn()
+		}()
+	}
+}
+
+func TestSizedEqual(t *testing.T) {
+	b := New(104)
+	b.SetAll()
+
+	s, s1 := NewSizedFromBitset(b, 100), NewSizedFromBitset(b, 101)
+
+	if s.Equal(s1) {
+		t.Error("Equal failed, different lengths should not be equal")
+	}
+
+	s1 = NewSized(100)
+	s1.SetAll()
+
+	if !s.Equal(s1) {
+		t.Error("Equal failed")
+	}
+
+	if exp := "Sized{100,ffffffffffffffffffffffff0f}"; s.String() != exp {
+		t.Errorf("String failed, expected %s, got %s", exp, s)
+	}
+}
+
+func TestSizedBitwise(t *testing.T) {
+	if err := quick.Check(func(b, b1 Bitset, size, _ uint) bool {
+		s, s1 := NewSizedFromBitset(b, size), NewSizedFromBitset(b1, size)
+
+		s2 := NewSized(size)
+		s2.SymmetricDifference(s, s1)
+
+		exp := New(b.Len())
+		exp.SymmetricDifference(b, b1)
+
+		return s2.Equal(NewSizedFromBitset(exp, size)) &&
+			s2.Count() == exp.CountRange(0, size)
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSizedMarshalBinary(t *testing.T) {
+	if err := quick.Check(func(b, _ Bitset, size, _ uint) bool {
+		s := NewSizedFromBitset(b, size)
+
+		data, err := s.MarshalBinary()
+		if err != nil {
+			return false
+		}
+
+		var s1 Sized
+		return s1.UnmarshalBinary(data) == nil && s1.Equal(s)
+	}, &quick.Config{
+		Values:        rangeTestValues2,
+		MaxCountScale: 100,
+	}); err != nil {
+		t.Error(err)
+	}
+
+	s := NewSized(100)
+	s.SetAll()
+
+	data, _ := s.MarshalBinary()
+
+	var b Bitset
+	if err := b.UnmarshalBinary(data); err != nil || b.Len() != 104 || b.Count() != 100 {
+		t.Errorf("Bitset.UnmarshalBinary failed, got %s (%v)", b, err)
+	}
+}
+
+func TestSizedBits(t *testing.T) {
+	s := NewSized(20)
+	s.PutBits(4, 16, 0xbeef)
+
+	if x := s.GetBits(4, 16); x != 0xbeef {
+		t.Errorf("GetBits failed, expected 0xbeef, got %#x", x)
+	}
+
+	if x := s.GetSignedBits(4, 16); x != int64(int16(-0x4111)) {
+		t.Errorf("GetSignedBits failed, got %d", x)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("PutBits did not panic past Len")
+		}
+	}()
+
+	s.PutBits(5, 16, 0)
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/tmthrgd/go-bitset/internal/bitwise"
+	gobitwise "github.com/tmthrgd/go-bitwise"
+	"github.com/tmthrgd/go-popcount"
+)
+
+// streamChunkSize is the number of bytes read from each
+// io.Reader at a time by the Stream* functions.
+const streamChunkSize = 64 << 10
+
+// The Stream* functions operate on the raw bytes of
+// Bitsets that are too large to hold in memory, reading
+// and writing them in chunks. To read from an io.ReaderAt,
+// wrap it with io.NewSectionReader.
+//
+// Like their in-memory counterparts, the set operations
+// stop at the end of the shortest input.
+
+// readChunks reads the next chunk from each of srcs into
+// bufs and slices chunks to the length of the shortest.
+func readChunks(srcs []io.Reader, bufs, chunks [][]byte) (n int, eof bool, err error) {
+	n = streamChunkSize
+
+	for i, r := range srcs {
+		m, err := io.ReadFull(r, bufs[i])
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			eof = true
+		default:
+			return 0, false, err
+		}
+
+		if m < n {
+			n = m
+		}
+	}
+
+	for i := range chunks {
+		chunks[i] = bufs[i][:n]
+	}
+
+	return n, eof, nil
+}
+
+func stream(w io.Writer, srcs []io.Reader, op func(dst []byte, chunks [][]byte)) (int64, error) {
+	if len(srcs) == 0 {
+		return 0, nil
+	}
+
+	bufs := make([][]byte, len(srcs))
+	for i := range bufs {
+		bufs[i] = make([]byte, streamChunkSize)
+	}
+
+	chunks := make([][]byte, len(srcs))
+
+	var written int64
+	for {
+		n, eof, err := readChunks(srcs, bufs, chunks)
+		if err != nil {
+			return written, err
+		}
+
+		if n != 0 {
+			op(chunks[0], chunks)
+
+			m, err := w.Write(chunks[0])
+			written += int64(m)
+
+			if err != nil {
+				return written, err
+			}
+		}
+
+		if eof {
+			return written, nil
+		}
+	}
+}
+
+// StreamUnion writes the union of srcs to w.
+func StreamUnion(w io.Writer, srcs ...io.Reader) (int64, error) {
+	return stream(w, srcs, func(dst []byte, chunks [][]byte) {
+		bitwise.OrN(dst, chunks)
+	})
+}
+
+// StreamIntersection writes the intersection of srcs to w.
+func StreamIntersection(w io.Writer, srcs ...io.Reader) (int64, error) {
+	return stream(w, srcs, func(dst []byte, chunks [][]byte) {
+		bitwise.AndN(dst, chunks)
+	})
+}
+
+// StreamDifference writes the bits set in r1 but not in
+// r2 to w.
+func StreamDifference(w io.Writer, r1, r2 io.Reader) (int64, error) {
+	return stream(w, []io.Reader{r1, r2}, func(dst []byte, chunks [][]byte) {
+		gobitwise.AndNot(dst, chunks[0], chunks[1])
+	})
+}
+
+// StreamSymmetricDifference writes the bits set in exactly
+// one of r1 and r2 to w.
+func StreamSymmetricDifference(w io.Writer, r1, r2 io.Reader) (int64, error) {
+	return stream(w, []io.Reader{r1, r2}, func(dst []byte, chunks [][]byte) {
+		gobitwise.XOR(dst, chunks[0], chunks[1])
+	})
+}
+
+// StreamCount returns the number of bits set in r.
+func StreamCount(r io.Reader) (uint64, error) {
+	buf := make([]byte, streamChunkSize)
+
+	var count uint64
+	for {
+		n, err := io.ReadFull(r, buf)
+		count += popcount.CountBytes(buf[:n])
+
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return count, nil
+		default:
+			return count, err
+		}
+	}
+}
+
+// StreamEqual reports whether r1 and r2 contain the same
+// bytes. Like Equal, Bitsets of different lengths are
+// never equal.
+func StreamEqual(r1, r2 io.Reader) (bool, error) {
+	buf1 := make([]byte, streamChunkSize)
+	buf2 := make([]byte, streamChunkSize)
+
+	for {
+		n1, err1 := io.ReadFull(r1, buf1)
+		if err1 != nil && err1 != io.EOF && err1 != io.ErrUnexpectedEOF {
+			return false, err1
+		}
+
+		n2, err2 := io.ReadFull(r2, buf2)
+		if err2 != nil && err2 != io.EOF && err2 != io.ErrUnexpectedEOF {
+			return false, err2
+		}
+
+		if !bytes.Equal(buf1[:n1], buf2[:n2]) {
+			return false, nil
+		}
+
+		if err1 != nil || err2 != nil {
+			return err1 != nil && err2 != nil, nil
+		}
+	}
+}
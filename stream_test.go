@@ -0,0 +1,184 @@
+// Copyright 2017 Tom Thorogood. All rights reserved.
+// Use of this source code is governed by a
+// Modified BSD License license that can be found in
+// the LICENSE file.
+
+package bitset
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/iotest"
+	"testing/quick"
+)
+
+func streamTestValues(args []reflect.Value, rand *rand.Rand) {
+	size := rand.Intn(3 * streamChunkSize)
+
+	srcs := make([]Bitset, 2+rand.Intn(3))
+	for i := range srcs {
+		if rand.Intn(4) == 0 {
+			srcs[i] = make(Bitset, rand.Intn(size+1))
+		} else {
+			srcs[i] = make(Bitset, size)
+		}
+
+		rand.Read(srcs[i])
+	}
+
+	args[0] = reflect.ValueOf(srcs)
+}
+
+func streamReaders(srcs []Bitset) []io.Reader {
+	rs := make([]io.Reader, len(srcs))
+	for i, src := range srcs {
+		switch i % 3 {
+		case 0:
+			rs[i] = bytes.NewReader(src)
+		case 1:
+			rs[i] = iotest.HalfReader(bytes.NewReader(src))
+		case 2:
+			rs[i] = io.NewSectionReader(bytes.NewReader(src), 0, int64(len(src)))
+		}
+	}
+
+	return rs
+}
+
+func TestStream(t *testing.T) {
+	for _, v := range []struct {
+		name   string
+		binary bool
+		stream func(w io.Writer, srcs []io.Reader) (int64, error)
+		fn     func(dst Bitset, srcs []Bitset)
+	}{
+		{"StreamUnion", false, func(w io.Writer, srcs []io.Reader) (int64, error) {
+			return StreamUnion(w, srcs...)
+		}, func(dst Bitset, srcs []Bitset) {
+			dst.UnionAll(srcs...)
+		}},
+		{"StreamIntersection", false, func(w io.Writer, srcs []io.Reader) (int64, error) {
+			return StreamIntersection(w, srcs...)
+		}, func(dst Bitset, srcs []Bitset) {
+			dst.IntersectionAll(srcs...)
+		}},
+		{"StreamDifference", true, func(w io.Writer, srcs []io.Reader) (int64, error) {
+			return StreamDifference(w, srcs[0], srcs[1])
+		}, func(dst Bitset, srcs []Bitset) {
+			dst.Difference(srcs[0], srcs[1])
+		}},
+		{"StreamSymmetricDifference", true, func(w io.Writer, srcs []io.Reader) (int64, error) {
+			return StreamSymmetricDifference(w, srcs[0], srcs[1])
+		}, func(dst Bitset, srcs []Bitset) {
+			dst.SymmetricDifference(srcs[0], srcs[1])
+		}},
+	} {
+		if err := quick.Check(func(srcs []Bitset) bool {
+			if v.binary {
+				srcs = srcs[:2]
+			}
+
+			var buf bytes.Buffer
+			n, err := v.stream(&buf, streamReaders(srcs))
+
+			dst := make(Bitset, minLen(srcs[0], srcs))
+			v.fn(dst, srcs)
+
+			return err == nil && n == int64(buf.Len()) && bytes.Equal(buf.Bytes(), dst)
+		}, &quick.Config{
+			Values:        streamTestValues,
+			MaxCountScale: 0.5,
+		}); err != nil {
+			t.Errorf("%s failed: %v", v.name, err)
+		}
+	}
+}
+
+func TestStreamCount(t *testing.T) {
+	if err := quick.Check(func(srcs []Bitset) bool {
+		count, err := StreamCount(iotest.HalfReader(bytes.NewReader(srcs[0])))
+		return err == nil && count == uint64(srcs[0].Count())
+	}, &quick.Config{
+		Values:        streamTestValues,
+		MaxCountScale: 0.5,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStreamEqual(t *testing.T) {
+	if err := quick.Check(func(srcs []Bitset) bool {
+		b := srcs[0]
+		b1 := srcs[len(srcs)-1]
+
+		for _, b1 := range []Bitset{b1, b.Clone(), b.Clone()[:len(b)/2]} {
+			eq, err := StreamEqual(bytes.NewReader(b), iotest.HalfReader(bytes.NewReader(b1)))
+			if err != nil || eq != b.Equal(b1) {
+				return false
+			}
+		}
+
+		return true
+	}, &quick.Config{
+		Values:        streamTestValues,
+		MaxCountScale: 0.5,
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	errTest := errors.New("test error")
+
+	b := New(8 * streamChunkSize)
+	r := io.MultiReader(bytes.NewReader(b), errReader{errTest})
+
+	if _, err := StreamUnion(ioutil.Discard, r, bytes.NewReader(b.Clone()[:0])); err != nil {
+		t.Errorf("StreamUnion returned %v, expected nil", err)
+	}
+
+	r = io.MultiReader(bytes.NewReader(b), errReader{errTest})
+
+	if _, err := StreamUnion(ioutil.Discard, r, bytes.NewReader(New(16*streamChunkSize))); err != errTest {
+		t.Errorf("StreamUnion returned %v, expected %v", err, errTest)
+	}
+
+	if _, err := StreamCount(errReader{errTest}); err != errTest {
+		t.Errorf("StreamCount returned %v, expected %v", err, errTest)
+	}
+
+	if _, err := StreamEqual(bytes.NewReader(b), errReader{errTest}); err != errTest {
+		t.Errorf("StreamEqual returned %v, expected %v", err, errTest)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func BenchmarkStreamUnion(b *testing.B) {
+	srcs := make([]Bitset, 8)
+	for i := range srcs {
+		srcs[i] = New(8 << 20)
+		rand.Read(srcs[i])
+	}
+
+	b.SetBytes(int64(len(srcs[0]) * len(srcs)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rs := make([]io.Reader, len(srcs))
+		for j, src := range srcs {
+			rs[j] = bytes.NewReader(src)
+		}
+
+		StreamUnion(ioutil.Discard, rs...)
+	}
+}